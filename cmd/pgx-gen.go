@@ -1,7 +1,10 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"go/token"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,11 +14,28 @@ import (
 )
 
 func main() {
-	if len(os.Args) < 2 || len(os.Args) > 3 {
+	args := os.Args[1:]
+	if len(args) != 0 && args[0] == "check-schema" {
+		CheckSchema(args[1:])
+		return
+	}
+	if len(args) != 0 && args[0] == "from-ddl" {
+		FromDDL(args[1:])
+		return
+	}
+	if len(args) != 0 && args[0] == "queries" {
+		Queries(args[1:])
+		return
+	}
+	ddl := len(args) != 0 && args[0] == "ddl"
+	if ddl {
+		args = args[1:]
+	}
+	if len(args) < 1 || len(args) > 2 {
 		Usage()
 		os.Exit(1)
 	}
-	path := os.Args[1]
+	path := args[0]
 	if path == "" {
 		Usage()
 		os.Exit(1)
@@ -26,37 +46,220 @@ func main() {
 		os.Exit(1)
 	}
 	var outpath string
-	if len(os.Args) == 3 {
-		outpath = os.Args[2]
-	} else {
+	if len(args) == 2 {
+		outpath = args[1]
+	} else if !ddl {
 		outpath = strings.TrimSuffix(path, filepath.Ext(path)) + "_pgxgen.go"
 	}
 
 	f := pgxgen.NewFile(af)
-	gen, err := f.Gen()
+	var gen []byte
+	if ddl {
+		gen = f.GenDDL()
+	} else {
+		gen, err = f.Gen()
+		if err != nil {
+			Err(err)
+			os.Exit(1)
+		}
+	}
+
+	out := os.Stdout
+	if outpath != "" {
+		out, err = os.Create(outpath)
+		if err != nil {
+			Err(err)
+			os.Exit(1)
+		}
+		defer out.Close()
+	}
+	_, err = out.Write(gen)
 	if err != nil {
 		Err(err)
 		os.Exit(1)
 	}
+}
 
-	out, err := os.Create(outpath)
+// CheckSchema compares the structs within each Go source file named by args
+// against the tables within a schema file, exiting with a non-zero status if
+// any mismatches are found
+func CheckSchema(args []string) {
+	fs := flag.NewFlagSet("check-schema", flag.ExitOnError)
+	fs.Usage = Usage
+	schemaPath := fs.String("schema", "", "")
+	fs.Parse(args)
+	if *schemaPath == "" || fs.NArg() == 0 {
+		Usage()
+		os.Exit(1)
+	}
+	src, err := ioutil.ReadFile(*schemaPath)
 	if err != nil {
 		Err(err)
 		os.Exit(1)
 	}
-	defer out.Close()
-	_, err = out.Write(gen)
+	schema, err := pgxgen.ParseSchema(string(src))
+	if err != nil {
+		Err(fmt.Errorf("%s: %v", *schemaPath, err))
+		os.Exit(1)
+	}
+	failed := false
+	for _, path := range fs.Args() {
+		af, err := astx.ParseFile(path)
+		if err != nil {
+			Err(err)
+			os.Exit(1)
+		}
+		mismatches, err := pgxgen.NewFile(af).CheckSchema(schema)
+		if err != nil {
+			Err(err)
+			os.Exit(1)
+		}
+		for _, m := range mismatches {
+			fmt.Fprintln(os.Stderr, m)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// FromDDL writes Go struct definitions for the tables within a schema file,
+// then generates code for the structs as usual
+func FromDDL(args []string) {
+	fs := flag.NewFlagSet("from-ddl", flag.ExitOnError)
+	fs.Usage = Usage
+	pkg := fs.String("pkg", "", "")
+	force := fs.Bool("force", false, "")
+	fs.Parse(args)
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		Usage()
+		os.Exit(1)
+	}
+	schemaPath := fs.Arg(0)
+	outpath := fs.Arg(1)
+	if outpath == "" {
+		outpath = strings.TrimSuffix(schemaPath, filepath.Ext(schemaPath)) + ".go"
+	}
+	genpath := strings.TrimSuffix(outpath, filepath.Ext(outpath)) + "_pgxgen.go"
+	if !*force {
+		for _, path := range []string{outpath, genpath} {
+			if _, err := os.Stat(path); err == nil {
+				Err(fmt.Errorf("%s already exists (use --force to overwrite)", path))
+				os.Exit(1)
+			}
+		}
+	}
+	if *pkg == "" {
+		*pkg = DefaultPkg(outpath)
+	}
+	src, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+	schema, err := pgxgen.ParseSchema(string(src))
+	if err != nil {
+		Err(fmt.Errorf("%s: %v", schemaPath, err))
+		os.Exit(1)
+	}
+	structs, warnings, err := pgxgen.GenStructs(*pkg, schema)
+	for _, w := range warnings {
+		Err(fmt.Errorf("%s: %s", schemaPath, w))
+	}
+	if err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(outpath, structs, 0644); err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+
+	af, err := astx.ParseFile(outpath)
+	if err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+	gen, err := pgxgen.NewFile(af).Gen()
 	if err != nil {
 		Err(err)
 		os.Exit(1)
 	}
+	if err = ioutil.WriteFile(genpath, gen, 0644); err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+}
+
+// Queries generates Go functions for the annotated queries within a queries
+// file
+func Queries(args []string) {
+	fs := flag.NewFlagSet("queries", flag.ExitOnError)
+	fs.Usage = Usage
+	pkg := fs.String("pkg", "", "")
+	fs.Parse(args)
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		Usage()
+		os.Exit(1)
+	}
+	path := fs.Arg(0)
+	outpath := fs.Arg(1)
+	if outpath == "" {
+		outpath = strings.TrimSuffix(path, filepath.Ext(path)) + "_pgxgen.go"
+	}
+	if *pkg == "" {
+		*pkg = DefaultPkg(outpath)
+	}
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+	queries, err := pgxgen.ParseQueries(string(src))
+	if err != nil {
+		Err(fmt.Errorf("%s: %v", path, err))
+		os.Exit(1)
+	}
+	gen, err := pgxgen.GenQueries(*pkg, path, queries)
+	if err != nil {
+		Err(fmt.Errorf("%s: %v", path, err))
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(outpath, gen, 0644); err != nil {
+		Err(err)
+		os.Exit(1)
+	}
+}
+
+// DefaultPkg returns the name of the directory containing outpath, or "models"
+// if the directory name is not a valid package name
+func DefaultPkg(outpath string) string {
+	abs, err := filepath.Abs(outpath)
+	if err != nil {
+		return "models"
+	}
+	pkg := filepath.Base(filepath.Dir(abs))
+	if !token.IsIdentifier(pkg) {
+		return "models"
+	}
+	return pkg
 }
 
 func Usage() {
 	fmt.Fprintf(os.Stderr, "Usage of %s:\n", os.Args[0])
-	fmt.Fprintf(os.Stderr, "\tpqx-gen filepath [outpath]\n\n")
+	fmt.Fprintf(os.Stderr, "\tpqx-gen filepath [outpath]\n")
+	fmt.Fprintf(os.Stderr, "\tpqx-gen ddl filepath [outpath]\n")
+	fmt.Fprintf(os.Stderr, "\tpqx-gen check-schema --schema schemapath filepath...\n")
+	fmt.Fprintf(os.Stderr, "\tpqx-gen from-ddl [--pkg name] [--force] schemapath [outpath]\n")
+	fmt.Fprintf(os.Stderr, "\tpqx-gen queries [--pkg name] queriespath [outpath]\n\n")
 	fmt.Fprintf(os.Stderr, "Defaults:\n")
-	fmt.Fprintf(os.Stderr, "\toutpath: filepath + \"_pgxgen.go\"\n\n")
+	fmt.Fprintf(os.Stderr, "\toutpath: filepath + \"_pgxgen.go\"\n")
+	fmt.Fprintf(os.Stderr, "\toutpath (ddl): stdout\n")
+	fmt.Fprintf(os.Stderr, "\toutpath (from-ddl): schemapath with a .go extension\n")
+	fmt.Fprintf(os.Stderr, "\toutpath (queries): queriespath + \"_pgxgen.go\"\n")
+	fmt.Fprintf(os.Stderr, "\tname (from-ddl, queries): the directory name of outpath\n")
+	fmt.Fprintf(os.Stderr, "\tforce (from-ddl): false, so existing files are not overwritten\n\n")
 }
 
 func Err(err error) {
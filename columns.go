@@ -1,6 +1,7 @@
 package pgxgen
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -8,17 +9,70 @@ import (
 )
 
 const (
-	ColumnTagName = "pgx"
-	ColumnNameKey = "name"
-	ColumnTypeKey = "type"
+	ColumnTagName    = "pgx"
+	ColumnNameKey    = "name"
+	ColumnTypeKey    = "type"
+	ColumnPKKey      = "pk"
+	ColumnUniqueKey  = "unique"
+	ColumnMergeKey   = "merge"
+	ColumnNullKey    = "null"
+	ColumnDefaultKey = "default"
+	// name of the JSON codec of json and jsonb columns (see
+	// pgtypes.RegisterJSONCodec)
+	ColumnJSONKey = "json"
+	// name of the time zone location of date and timestamp columns (see
+	// pgtypes.GetLocation)
+	ColumnTZKey = "tz"
+	// precision and scale of numeric columns, which are taken from the type
+	// option if given as numeric(p,s) or numeric(p,s)[]
+	ColumnPrecisionKey = "precision"
+	ColumnScaleKey     = "scale"
 )
 
+// Merge rules for the merge option, which control how a column is updated
+// when an upsert conflicts with an existing row:
+const (
+	// The existing value is replaced with the inserted value (the default)
+	MergeOverwrite = "overwrite"
+	// The existing value is kept. The column is still inserted by default, so
+	// new rows take the inserted value
+	MergeKeep = "keep"
+	// The existing value is replaced with the inserted value, unless the
+	// inserted value is null
+	MergeCoalesce = "coalesce"
+	// The column is left out of upserts by default, so new rows take the
+	// column default and existing rows are not updated. If named explicitly,
+	// the column is inserted and then merged as with MergeKeep
+	MergeSkip = "skip"
+)
+
+// SQLNullTypes contains the value type and value field name of each
+// database/sql null wrapper type. Generic sql.Null[T] types hold a value of
+// type T within field V.
+var SQLNullTypes = map[string][2]string{
+	"sql.NullBool":    {"bool", "Bool"},
+	"sql.NullByte":    {"byte", "Byte"},
+	"sql.NullInt16":   {"int16", "Int16"},
+	"sql.NullInt32":   {"int32", "Int32"},
+	"sql.NullInt64":   {"int64", "Int64"},
+	"sql.NullFloat64": {"float64", "Float64"},
+	"sql.NullString":  {"string", "String"},
+	"sql.NullTime":    {"time.Time", "Time"},
+}
+
 type Column struct {
 	Name, Type  string
 	StructField *astx.StructField
 	Spec        map[string]string
 	EncodeOp    Op
 	DecodeOp    Op
+	// NullValue holds the name of the value field if the field type is a
+	// database/sql null wrapper type, in which case the ops are those of the
+	// wrapped value type (see SQLNullTypes)
+	NullValue string
+	// ValueType holds the wrapped value type of a database/sql null wrapper
+	// type, or the field type otherwise
+	ValueType string
 }
 
 func IsColumn(f astx.StructField) bool {
@@ -34,22 +88,134 @@ func NewColumn(f *astx.StructField) *Column {
 		Type:        coltype,
 		StructField: f,
 		Spec:        spec,
+		ValueType:   f.Type,
 	}
-	if Encoders[f.Type] != nil {
-		col.EncodeOp = Encoders[f.Type][coltype]
+	if valueType, valueField := SQLNullValue(f.Type); valueField != "" {
+		col.ValueType, col.NullValue = valueType, valueField
+	}
+	if Encoders[col.ValueType] != nil {
+		col.EncodeOp = Encoders[col.ValueType][coltype]
 	}
 	if Decoders[coltype] != nil {
-		col.DecodeOp = Decoders[coltype][f.Type]
+		col.DecodeOp = Decoders[coltype][col.ValueType]
 	}
 	return col
 }
 
+// SQLNullValue returns the value type and value field name of ftype if ftype
+// is a database/sql null wrapper type, or empty strings otherwise. Wrapped
+// pointer types are not supported.
+func SQLNullValue(ftype string) (valueType, valueField string) {
+	if t, ok := SQLNullTypes[ftype]; ok {
+		return t[0], t[1]
+	}
+	if strings.HasPrefix(ftype, "sql.Null[") && strings.HasSuffix(ftype, "]") {
+		valueType = strings.TrimSpace(ftype[len("sql.Null[") : len(ftype)-1])
+		if valueType != "" && valueType[0] != '*' {
+			return valueType, "V"
+		}
+	}
+	return "", ""
+}
+
+// IsPK reports whether c is (part of) the primary key of its table.
+func (c *Column) IsPK() bool {
+	return c.Spec[ColumnPKKey] == "1"
+}
+
+// UniqueGroup returns the name of the unique constraint which c is part of, or
+// "" if c is not unique. Columns tagged with a bare unique option form their
+// own single-column group; columns tagged unique:{name} share a group.
+func (c *Column) UniqueGroup() string {
+	switch group := c.Spec[ColumnUniqueKey]; group {
+	case "", "0":
+		return ""
+	case "1":
+		return c.Name
+	default:
+		return group
+	}
+}
+
+// Merge returns the upsert merge rule for c.
+func (c *Column) Merge() string {
+	if merge := c.Spec[ColumnMergeKey]; merge != "" {
+		return merge
+	}
+	return MergeOverwrite
+}
+
+// NotNull reports whether c should be declared NOT NULL. Unless the null option
+// is given, columns are nullable if their field type is a pointer, one of the
+// pgx.Null* types or a database/sql null wrapper type. Primary key columns are
+// never nullable.
+func (c *Column) NotNull() bool {
+	if c.IsPK() {
+		return true
+	}
+	switch c.Spec[ColumnNullKey] {
+	case "1":
+		return false
+	case "0":
+		return true
+	}
+	return !c.NullableField()
+}
+
+// NullableField reports whether the field type of c may hold a null value: a
+// pointer, one of the pgx.Null* types or a database/sql null wrapper type.
+func (c *Column) NullableField() bool {
+	ftype := c.StructField.Type
+	return strings.HasPrefix(ftype, "*") || strings.HasPrefix(ftype, "pgx.Null") || c.NullValue != ""
+}
+
+// Default returns the default value expression for c, or "" if c has no
+// default.
+func (c *Column) Default() string {
+	return c.Spec[ColumnDefaultKey]
+}
+
+// JSONCodec returns the name of the JSON codec for c, or "" if the default
+// codec should be used.
+func (c *Column) JSONCodec() string {
+	return c.Spec[ColumnJSONKey]
+}
+
+// Location returns the name of the time zone location for c, or "" if the
+// default location should be used.
+func (c *Column) Location() string {
+	return c.Spec[ColumnTZKey]
+}
+
+// Numeric returns the precision and scale of c, if c is a numeric or numeric[]
+// column with a declared precision, or zeros otherwise.
+func (c *Column) Numeric() (precision, scale int) {
+	if c.Type != "numeric" && c.Type != "numeric[]" {
+		return 0, 0
+	}
+	precision, _ = strconv.Atoi(c.Spec[ColumnPrecisionKey])
+	if precision <= 0 {
+		return 0, 0
+	}
+	scale, _ = strconv.Atoi(c.Spec[ColumnScaleKey])
+	return precision, scale
+}
+
+// SQLType returns the data type of c as declared within DDL, including the
+// precision and scale of numeric and numeric[] columns.
+func (c *Column) SQLType() string {
+	if precision, scale := c.Numeric(); precision != 0 {
+		return fmt.Sprintf("numeric(%d,%d)%s", precision, scale, strings.TrimPrefix(c.Type, "numeric"))
+	}
+	return c.Type
+}
+
 func GetFieldColumnSpec(f *astx.StructField) map[string]string {
 	spec := map[string]string{}
 	t := f.Tag.Get(ColumnTagName)
 	split := strings.Split(t, ";")
 	for _, pair := range split {
-		kv := strings.Split(pair, ":")
+		kv := strings.SplitN(pair, ":", 2)
 		if len(kv) == 0 {
 			continue
 		}
@@ -61,6 +227,14 @@ func GetFieldColumnSpec(f *astx.StructField) map[string]string {
 			v := strings.TrimSpace(kv[1])
 			if k == ColumnTypeKey {
 				spec[ColumnTypeKey] = NormalizeDataType(v)
+				if precision, scale, ok := NumericTypmod(strings.TrimSuffix(v, "[]")); ok {
+					spec[ColumnPrecisionKey] = strconv.Itoa(precision)
+					spec[ColumnScaleKey] = strconv.Itoa(scale)
+				}
+				continue
+			}
+			if k == ColumnDefaultKey {
+				spec[ColumnDefaultKey] = v
 				continue
 			}
 			boolVal, err := strconv.ParseBool(v)
@@ -0,0 +1,49 @@
+package pgxgen
+
+import (
+	"strings"
+
+	"github.com/wdamron/pgx-gen/pgtypes"
+)
+
+// CreateTableSQL returns a CREATE TABLE statement for the table of s, with
+// column types, NOT NULL and DEFAULT clauses, and primary key and unique
+// constraints taken from the column specs of s.
+func (s *Struct) CreateTableSQL() string {
+	defs := make([]string, 0, len(s.Columns)+1)
+	for _, c := range s.Columns {
+		def := pgtypes.QuoteIdentifier(c.Name) + " " + c.SQLType()
+		if c.NotNull() {
+			def += " NOT NULL"
+		}
+		if dflt := c.Default(); dflt != "" {
+			def += " DEFAULT " + dflt
+		}
+		defs = append(defs, def)
+	}
+	if pk := s.PKColumns(); len(pk) != 0 {
+		names := make([]string, len(pk))
+		for i, c := range pk {
+			names[i] = pgtypes.QuoteIdentifier(c.Name)
+		}
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(names, ", ")+")")
+	}
+	groups, order := s.UniqueGroups()
+	for _, group := range order {
+		defs = append(defs, "UNIQUE ("+strings.Join(pgtypes.QuoteIdentifiers(groups[group]), ", ")+")")
+	}
+	return "CREATE TABLE " + pgtypes.QuoteTableName(s.Table) + " (\n\t" + strings.Join(defs, ",\n\t") + "\n)"
+}
+
+// GenDDL generates CREATE TABLE statements for each struct of f which has
+// columns, returning bytes
+func (f *File) GenDDL() []byte {
+	out := ""
+	for _, s := range f.Structs {
+		if len(s.Columns) == 0 {
+			continue
+		}
+		out += s.CreateTableSQL() + ";\n\n"
+	}
+	return []byte(strings.TrimSuffix(out, "\n"))
+}
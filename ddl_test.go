@@ -0,0 +1,33 @@
+package pgxgen
+
+import (
+	"testing"
+)
+
+func TestCreateTableSQL(t *testing.T) {
+	f := parseTestFile(t, "test.go", "package p\n\ntype Row struct {\n"+
+		"\t_ struct{} `pgx:\"table:app.rows\"`\n"+
+		"\tID int64 `pgx:\"name:id;type:int8;pk\"`\n"+
+		"\tA *int32 `pgx:\"name:a;type:int4;default:0\"`\n"+
+		"\tB string `pgx:\"name:b;type:text;unique:ab\"`\n"+
+		"\tC string `pgx:\"name:Order;type:varchar;unique:ab;null\"`\n"+
+		"\tD []float64 `pgx:\"name:d;type:float8[];unique\"`\n"+
+		"}\n\ntype NoColumns struct{}\n")
+	want := "CREATE TABLE \"app\".\"rows\" (\n" +
+		"\t\"id\" int8 NOT NULL,\n" +
+		"\t\"a\" int4 DEFAULT 0,\n" +
+		"\t\"b\" text NOT NULL,\n" +
+		"\t\"Order\" varchar,\n" +
+		"\t\"d\" float[] NOT NULL,\n" +
+		"\tPRIMARY KEY (\"id\"),\n" +
+		"\tUNIQUE (\"b\", \"Order\"),\n" +
+		"\tUNIQUE (\"d\")\n" +
+		")"
+	if sql := f.Structs[0].CreateTableSQL(); sql != want {
+		t.Errorf("CreateTableSQL =\n%s\nwant\n%s", sql, want)
+	}
+	// structs without columns are left out
+	if ddl := string(f.GenDDL()); ddl != want+";\n" {
+		t.Errorf("GenDDL =\n%s", ddl)
+	}
+}
@@ -8,6 +8,7 @@ var Decoders = map[string]OpMap{
 		"*pgx.NullBool": OpCustomScan,
 	},
 	"int2": {
+		"byte":           OpAssign | OpCastByte | OpCheckOverflow,
 		"int":            OpAssign | OpCastInt,
 		"*int":           OpPtrAssign | OpCastInt,
 		"uint":           OpAssign | OpCastUint,
@@ -16,8 +17,8 @@ var Decoders = map[string]OpMap{
 		"*int16":         OpPtrAssign,
 		"uint16":         OpAssign | OpCastUint16 | OpCheckOverflow,
 		"*uint16":        OpPtrAssign | OpCastUint16 | OpCheckOverflow,
-		"int32":          OpAssign | OpCastUint32,
-		"*int32":         OpPtrAssign | OpCastUint32,
+		"int32":          OpAssign | OpCastInt32,
+		"*int32":         OpPtrAssign | OpCastInt32,
 		"uint32":         OpAssign | OpCastUint32,
 		"*uint32":        OpPtrAssign | OpCastUint32,
 		"int64":          OpAssign | OpCastInt64,
@@ -28,6 +29,7 @@ var Decoders = map[string]OpMap{
 		"*pgx.NullInt16": OpCustomScan,
 	},
 	"int4": {
+		"byte":           OpAssign | OpCastByte | OpCheckOverflow,
 		"int":            OpAssign | OpCastInt,
 		"*int":           OpPtrAssign | OpCastInt,
 		"uint":           OpAssign | OpCastUint,
@@ -48,6 +50,7 @@ var Decoders = map[string]OpMap{
 		"*pgx.NullInt32": OpCustomScan,
 	},
 	"int8": {
+		"byte":           OpAssign | OpCastByte | OpCheckOverflow,
 		"int":            OpAssign | OpCastInt,
 		"*int":           OpPtrAssign | OpCastInt,
 		"uint":           OpAssign | OpCastUint,
@@ -106,60 +109,118 @@ var Decoders = map[string]OpMap{
 		"*pgx.NullString": OpCustomScan,
 	},
 	"date": {
-		"time.Time":  OpAssign,
-		"*time.Time": OpPtrAssign,
+		"time.Time":             OpAssign,
+		"*time.Time":            OpPtrAssign,
+		"pgtypes.InfinityTime":  OpInfinityDecode,
+		"*pgtypes.InfinityTime": OpPtrAssign | OpInfinityDecode,
 	},
 	"timestamp": {
-		"time.Time":     OpAssign,
-		"*time.Time":    OpPtrAssign,
-		"pgx.NullTime":  OpCustomScan,
-		"*pgx.NullTime": OpCustomScan,
+		"time.Time":             OpAssign,
+		"*time.Time":            OpPtrAssign,
+		"pgx.NullTime":          OpCustomScan,
+		"*pgx.NullTime":         OpCustomScan,
+		"pgtypes.InfinityTime":  OpInfinityDecode,
+		"*pgtypes.InfinityTime": OpPtrAssign | OpInfinityDecode,
 	},
 	"timestampTz": {
-		"time.Time":     OpAssign,
-		"*time.Time":    OpPtrAssign,
-		"pgx.NullTime":  OpCustomScan,
-		"*pgx.NullTime": OpCustomScan,
+		"time.Time":             OpAssign,
+		"*time.Time":            OpPtrAssign,
+		"pgx.NullTime":          OpCustomScan,
+		"*pgx.NullTime":         OpCustomScan,
+		"pgtypes.InfinityTime":  OpInfinityDecode,
+		"*pgtypes.InfinityTime": OpPtrAssign | OpInfinityDecode,
 	},
 	"bool[]": {
-		"[]bool":  OpAssign,
-		"*[]bool": OpPtrAssign,
+		"[]bool":          OpAssign,
+		"*[]bool":         OpPtrAssign,
+		"[]*bool":         OpNullElemDecode,
+		"*[]*bool":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullBool":  OpNullElemDecode,
+		"*[]pgx.NullBool": OpPtrAssign | OpNullElemDecode,
 	},
 	"int2[]": {
-		"[]int16":  OpAssign,
-		"*[]int16": OpPtrAssign,
+		"[]int16":          OpAssign,
+		"*[]int16":         OpPtrAssign,
+		"[]*int16":         OpNullElemDecode,
+		"*[]*int16":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullInt16":  OpNullElemDecode,
+		"*[]pgx.NullInt16": OpPtrAssign | OpNullElemDecode,
 	},
 	"int4[]": {
-		"[]int32":  OpAssign,
-		"*[]int32": OpPtrAssign,
+		"[]int32":          OpAssign,
+		"*[]int32":         OpPtrAssign,
+		"[]*int32":         OpNullElemDecode,
+		"*[]*int32":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullInt32":  OpNullElemDecode,
+		"*[]pgx.NullInt32": OpPtrAssign | OpNullElemDecode,
 	},
 	"int8[]": {
-		"[]int64":  OpAssign,
-		"*[]int64": OpPtrAssign,
+		"[]int64":          OpAssign,
+		"*[]int64":         OpPtrAssign,
+		"[]*int64":         OpNullElemDecode,
+		"*[]*int64":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullInt64":  OpNullElemDecode,
+		"*[]pgx.NullInt64": OpPtrAssign | OpNullElemDecode,
 	},
 	"real[]": {
-		"[]float32":  OpAssign,
-		"*[]float32": OpPtrAssign,
+		"[]float32":          OpAssign,
+		"*[]float32":         OpPtrAssign,
+		"[]*float32":         OpNullElemDecode,
+		"*[]*float32":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullFloat32":  OpNullElemDecode,
+		"*[]pgx.NullFloat32": OpPtrAssign | OpNullElemDecode,
 	},
 	"float[]": {
-		"[]float64":  OpAssign,
-		"*[]float64": OpPtrAssign,
+		"[]float64":          OpAssign,
+		"*[]float64":         OpPtrAssign,
+		"[]*float64":         OpNullElemDecode,
+		"*[]*float64":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullFloat64":  OpNullElemDecode,
+		"*[]pgx.NullFloat64": OpPtrAssign | OpNullElemDecode,
 	},
 	"text[]": {
-		"[]string":  OpAssign,
-		"*[]string": OpPtrAssign,
+		"[]string":          OpAssign,
+		"*[]string":         OpPtrAssign,
+		"[]*string":         OpNullElemDecode,
+		"*[]*string":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullString":  OpNullElemDecode,
+		"*[]pgx.NullString": OpPtrAssign | OpNullElemDecode,
 	},
 	"varchar[]": {
-		"[]string":  OpAssign,
-		"*[]string": OpPtrAssign,
+		"[]string":          OpAssign,
+		"*[]string":         OpPtrAssign,
+		"[]*string":         OpNullElemDecode,
+		"*[]*string":        OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullString":  OpNullElemDecode,
+		"*[]pgx.NullString": OpPtrAssign | OpNullElemDecode,
 	},
 	"timestamp[]": {
-		"[]time.Time":  OpAssign,
-		"*[]time.Time": OpPtrAssign,
+		"[]time.Time":     OpAssign,
+		"*[]time.Time":    OpPtrAssign,
+		"[]*time.Time":    OpNullElemDecode,
+		"*[]*time.Time":   OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullTime":  OpNullElemDecode,
+		"*[]pgx.NullTime": OpPtrAssign | OpNullElemDecode,
 	},
 	"timestampTz[]": {
-		"[]time.Time":  OpAssign,
-		"*[]time.Time": OpPtrAssign,
+		"[]time.Time":     OpAssign,
+		"*[]time.Time":    OpPtrAssign,
+		"[]*time.Time":    OpNullElemDecode,
+		"*[]*time.Time":   OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullTime":  OpNullElemDecode,
+		"*[]pgx.NullTime": OpPtrAssign | OpNullElemDecode,
+	},
+	"date[]": {
+		"[]time.Time":     OpAssign,
+		"*[]time.Time":    OpPtrAssign,
+		"[]*time.Time":    OpNullElemDecode,
+		"*[]*time.Time":   OpPtrAssign | OpNullElemDecode,
+		"[]pgx.NullTime":  OpNullElemDecode,
+		"*[]pgx.NullTime": OpPtrAssign | OpNullElemDecode,
+	},
+	"bytea[]": {
+		"[][]byte":  OpAssign,
+		"*[][]byte": OpPtrAssign,
 	},
 	"hstore": {
 		"pgx.Hstore":         OpAssign,
@@ -175,4 +236,120 @@ var Decoders = map[string]OpMap{
 		"uuid.UUID":  OpUuidDecode,
 		"*uuid.UUID": OpPtrAssign | OpUuidDecode,
 	},
+	"uuid[]": {
+		"[]string":     OpUuidDecode | OpUuidStringDecode,
+		"*[]string":    OpPtrAssign | OpUuidDecode | OpUuidStringDecode,
+		"[]uuid.UUID":  OpAssign,
+		"*[]uuid.UUID": OpPtrAssign,
+	},
+	"numeric": {
+		"*big.Rat": OpNumericDecode,
+		"*big.Int": OpNumericDecode,
+		"string":   OpNumericDecode,
+		"*string":  OpPtrAssign | OpNumericDecode,
+		"int64":    OpNumericDecode | OpCheckOverflow,
+		"*int64":   OpPtrAssign | OpNumericDecode | OpCheckOverflow,
+		"float64":  OpNumericDecode | OpCheckOverflow,
+		"*float64": OpPtrAssign | OpNumericDecode | OpCheckOverflow,
+	},
+	"numeric[]": {
+		"[]*big.Rat":  OpNumericDecode,
+		"*[]*big.Rat": OpPtrAssign | OpNumericDecode,
+		"[]string":    OpNumericDecode,
+		"*[]string":   OpPtrAssign | OpNumericDecode,
+	},
+	"interval": {
+		"pgtypes.Interval":  OpAssign,
+		"*pgtypes.Interval": OpPtrAssign,
+		"time.Duration":     OpDurationDecode,
+		"*time.Duration":    OpPtrAssign | OpDurationDecode,
+	},
+	"interval[]": {
+		"[]pgtypes.Interval":  OpAssign,
+		"*[]pgtypes.Interval": OpPtrAssign,
+		"[]time.Duration":     OpDurationDecode,
+		"*[]time.Duration":    OpPtrAssign | OpDurationDecode,
+	},
+	"time": {
+		"time.Time":          OpAssign,
+		"*time.Time":         OpPtrAssign,
+		"time.Duration":      OpDurationDecode,
+		"*time.Duration":     OpPtrAssign | OpDurationDecode,
+		"pgtypes.TimeOfDay":  OpTimeOfDayDecode,
+		"*pgtypes.TimeOfDay": OpPtrAssign | OpTimeOfDayDecode,
+	},
+	"timetz": {
+		"time.Time":          OpAssign,
+		"*time.Time":         OpPtrAssign,
+		"pgtypes.TimeOfDay":  OpTimeOfDayDecode,
+		"*pgtypes.TimeOfDay": OpPtrAssign | OpTimeOfDayDecode,
+	},
+	"time[]": {
+		"[]time.Time":          OpAssign,
+		"*[]time.Time":         OpPtrAssign,
+		"[]time.Duration":      OpDurationDecode,
+		"*[]time.Duration":     OpPtrAssign | OpDurationDecode,
+		"[]pgtypes.TimeOfDay":  OpTimeOfDayDecode,
+		"*[]pgtypes.TimeOfDay": OpPtrAssign | OpTimeOfDayDecode,
+	},
+	"timetz[]": {
+		"[]time.Time":          OpAssign,
+		"*[]time.Time":         OpPtrAssign,
+		"[]pgtypes.TimeOfDay":  OpTimeOfDayDecode,
+		"*[]pgtypes.TimeOfDay": OpPtrAssign | OpTimeOfDayDecode,
+	},
+	"inet": {
+		"net.IP":        OpNetDecode,
+		"*net.IP":       OpPtrAssign | OpNetDecode,
+		"*net.IPNet":    OpNetDecode,
+		"netip.Addr":    OpNetDecode,
+		"*netip.Addr":   OpPtrAssign | OpNetDecode,
+		"netip.Prefix":  OpNetDecode,
+		"*netip.Prefix": OpPtrAssign | OpNetDecode,
+	},
+	"inet[]": {
+		"[]net.IP":        OpNetDecode,
+		"*[]net.IP":       OpPtrAssign | OpNetDecode,
+		"[]*net.IPNet":    OpNetDecode,
+		"*[]*net.IPNet":   OpPtrAssign | OpNetDecode,
+		"[]netip.Addr":    OpNetDecode,
+		"*[]netip.Addr":   OpPtrAssign | OpNetDecode,
+		"[]netip.Prefix":  OpNetDecode,
+		"*[]netip.Prefix": OpPtrAssign | OpNetDecode,
+	},
+	"cidr": {
+		"net.IP":        OpNetDecode,
+		"*net.IP":       OpPtrAssign | OpNetDecode,
+		"*net.IPNet":    OpNetDecode,
+		"netip.Addr":    OpNetDecode,
+		"*netip.Addr":   OpPtrAssign | OpNetDecode,
+		"netip.Prefix":  OpNetDecode,
+		"*netip.Prefix": OpPtrAssign | OpNetDecode,
+	},
+	"cidr[]": {
+		"[]net.IP":        OpNetDecode,
+		"*[]net.IP":       OpPtrAssign | OpNetDecode,
+		"[]*net.IPNet":    OpNetDecode,
+		"*[]*net.IPNet":   OpPtrAssign | OpNetDecode,
+		"[]netip.Addr":    OpNetDecode,
+		"*[]netip.Addr":   OpPtrAssign | OpNetDecode,
+		"[]netip.Prefix":  OpNetDecode,
+		"*[]netip.Prefix": OpPtrAssign | OpNetDecode,
+	},
+	"macaddr": {
+		"net.HardwareAddr":  OpAssign,
+		"*net.HardwareAddr": OpPtrAssign,
+	},
+	"macaddr[]": {
+		"[]net.HardwareAddr":  OpAssign,
+		"*[]net.HardwareAddr": OpPtrAssign,
+	},
+	"macaddr8": {
+		"net.HardwareAddr":  OpAssign,
+		"*net.HardwareAddr": OpPtrAssign,
+	},
+	"macaddr8[]": {
+		"[]net.HardwareAddr":  OpAssign,
+		"*[]net.HardwareAddr": OpPtrAssign,
+	},
 }
@@ -27,6 +27,11 @@ var Encoders = map[string]OpMap{
 		"int4": OpDerefPass | OpCastInt32 | OpCheckOverflow,
 		"int8": OpDerefPass | OpCastInt64 | OpCheckOverflow,
 	},
+	"byte": {
+		"int2": OpPass | OpCastInt16,
+		"int4": OpPass | OpCastInt32,
+		"int8": OpPass | OpCastInt64,
+	},
 	"int16": {
 		"int2": OpPass,
 		"int4": OpPass | OpCastInt32,
@@ -68,14 +73,16 @@ var Encoders = map[string]OpMap{
 		"int8": OpDerefPass | OpCastInt64,
 	},
 	"int64": {
-		"int2": OpPass | OpCastInt16 | OpCheckOverflow,
-		"int4": OpPass | OpCastInt32 | OpCheckOverflow,
-		"int8": OpPass,
+		"int2":    OpPass | OpCastInt16 | OpCheckOverflow,
+		"int4":    OpPass | OpCastInt32 | OpCheckOverflow,
+		"int8":    OpPass,
+		"numeric": OpPass | OpNumericEncode,
 	},
 	"*int64": {
-		"int2": OpDerefPass | OpCastInt16 | OpCheckOverflow,
-		"int4": OpDerefPass | OpCastInt32 | OpCheckOverflow,
-		"int8": OpDerefPass,
+		"int2":    OpDerefPass | OpCastInt16 | OpCheckOverflow,
+		"int4":    OpDerefPass | OpCastInt32 | OpCheckOverflow,
+		"int8":    OpDerefPass,
+		"numeric": OpDerefPass | OpNumericEncode,
 	},
 	"uint64": {
 		"int2": OpPass | OpCastInt16 | OpCheckOverflow,
@@ -96,24 +103,28 @@ var Encoders = map[string]OpMap{
 		"float": OpDerefPass | OpCastFloat64,
 	},
 	"float64": {
-		"real":  OpPass | OpCastFloat32 | OpCheckOverflow,
-		"float": OpPass,
+		"real":    OpPass | OpCastFloat32 | OpCheckOverflow,
+		"float":   OpPass,
+		"numeric": OpPass | OpNumericEncode,
 	},
 	"*float64": {
-		"real":  OpDerefPass | OpCastFloat32 | OpCheckOverflow,
-		"float": OpDerefPass,
+		"real":    OpDerefPass | OpCastFloat32 | OpCheckOverflow,
+		"float":   OpDerefPass,
+		"numeric": OpDerefPass | OpNumericEncode,
 	},
 	"string": {
 		"bytea":   OpPass | OpCastBytes,
 		"text":    OpPass,
 		"varchar": OpPass,
 		"uuid":    OpPass | OpUuidEncode | OpUuidStringEncode,
+		"numeric": OpPass | OpNumericEncode,
 	},
 	"*string": {
 		"bytea":   OpDerefPass | OpCastBytes,
 		"text":    OpDerefPass,
 		"varchar": OpDerefPass,
 		"uuid":    OpDerefPass | OpUuidEncode | OpUuidStringEncode,
+		"numeric": OpDerefPass | OpNumericEncode,
 	},
 	"[]byte": {
 		"bytea":   OpPass,
@@ -129,11 +140,15 @@ var Encoders = map[string]OpMap{
 		"date":        OpPass,
 		"timestamp":   OpPass,
 		"timestampTz": OpPass,
+		"time":        OpPass,
+		"timetz":      OpPass,
 	},
 	"*time.Time": {
 		"date":        OpDerefPass,
 		"timestamp":   OpDerefPass,
 		"timestampTz": OpDerefPass,
+		"time":        OpDerefPass,
+		"timetz":      OpDerefPass,
 	},
 	"[]bool": {
 		"bool[]": OpPass,
@@ -174,18 +189,34 @@ var Encoders = map[string]OpMap{
 	"[]string": {
 		"text[]":    OpPass,
 		"varchar[]": OpPass,
+		"uuid[]":    OpPass | OpUuidEncode | OpUuidStringEncode,
+		"numeric[]": OpPass | OpNumericEncode,
 	},
 	"*[]string": {
 		"text[]":    OpDerefPass,
 		"varchar[]": OpDerefPass,
+		"uuid[]":    OpDerefPass | OpUuidEncode | OpUuidStringEncode,
+		"numeric[]": OpDerefPass | OpNumericEncode,
 	},
 	"[]time.Time": {
+		"date[]":        OpPass,
 		"timestamp[]":   OpPass,
 		"timestampTz[]": OpPass,
+		"time[]":        OpPass,
+		"timetz[]":      OpPass,
 	},
 	"*[]time.Time": {
+		"date[]":        OpDerefPass,
 		"timestamp[]":   OpPass,
 		"timestampTz[]": OpPass,
+		"time[]":        OpDerefPass,
+		"timetz[]":      OpDerefPass,
+	},
+	"[][]byte": {
+		"bytea[]": OpPass,
+	},
+	"*[][]byte": {
+		"bytea[]": OpDerefPass,
 	},
 
 	// pgx built-in encoders:
@@ -248,6 +279,116 @@ var Encoders = map[string]OpMap{
 		"hstore": OpCustomEncode,
 	},
 
+	// arrays with NULL elements:
+	"[]*bool": {
+		"bool[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*bool": {
+		"bool[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullBool": {
+		"bool[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullBool": {
+		"bool[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*int16": {
+		"int2[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*int16": {
+		"int2[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullInt16": {
+		"int2[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullInt16": {
+		"int2[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*int32": {
+		"int4[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*int32": {
+		"int4[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullInt32": {
+		"int4[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullInt32": {
+		"int4[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*int64": {
+		"int8[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*int64": {
+		"int8[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullInt64": {
+		"int8[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullInt64": {
+		"int8[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*float32": {
+		"real[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*float32": {
+		"real[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullFloat32": {
+		"real[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullFloat32": {
+		"real[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*float64": {
+		"float[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*float64": {
+		"float[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullFloat64": {
+		"float[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullFloat64": {
+		"float[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*string": {
+		"text[]":    OpPass | OpNullElemEncode,
+		"varchar[]": OpPass | OpNullElemEncode,
+	},
+	"*[]*string": {
+		"text[]":    OpDerefPass | OpNullElemEncode,
+		"varchar[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullString": {
+		"text[]":    OpPass | OpNullElemEncode,
+		"varchar[]": OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullString": {
+		"text[]":    OpDerefPass | OpNullElemEncode,
+		"varchar[]": OpDerefPass | OpNullElemEncode,
+	},
+	"[]*time.Time": {
+		"timestamp[]":   OpPass | OpNullElemEncode,
+		"timestampTz[]": OpPass | OpNullElemEncode,
+		"date[]":        OpPass | OpNullElemEncode,
+	},
+	"*[]*time.Time": {
+		"timestamp[]":   OpDerefPass | OpNullElemEncode,
+		"timestampTz[]": OpDerefPass | OpNullElemEncode,
+		"date[]":        OpDerefPass | OpNullElemEncode,
+	},
+	"[]pgx.NullTime": {
+		"timestamp[]":   OpPass | OpNullElemEncode,
+		"timestampTz[]": OpPass | OpNullElemEncode,
+		"date[]":        OpPass | OpNullElemEncode,
+	},
+	"*[]pgx.NullTime": {
+		"timestamp[]":   OpDerefPass | OpNullElemEncode,
+		"timestampTz[]": OpDerefPass | OpNullElemEncode,
+		"date[]":        OpDerefPass | OpNullElemEncode,
+	},
+
 	// wrappers around pgx built-in encoders:
 	"map[string]string": {
 		"hstore": OpHstoreMapEncode,
@@ -269,4 +410,152 @@ var Encoders = map[string]OpMap{
 	"*uuid.UUID": {
 		"uuid": OpDerefPass | OpUuidEncode,
 	},
+	"[]uuid.UUID": {
+		"uuid[]": OpPass,
+	},
+	"*[]uuid.UUID": {
+		"uuid[]": OpDerefPass,
+	},
+	"*big.Rat": {
+		"numeric": OpPass | OpNumericEncode,
+	},
+	"*big.Int": {
+		"numeric": OpPass | OpNumericEncode,
+	},
+	"pgtypes.Interval": {
+		"interval": OpPass,
+	},
+	"*pgtypes.Interval": {
+		"interval": OpDerefPass,
+	},
+	"[]pgtypes.Interval": {
+		"interval[]": OpPass,
+	},
+	"*[]pgtypes.Interval": {
+		"interval[]": OpDerefPass,
+	},
+	"time.Duration": {
+		"interval": OpPass | OpDurationEncode,
+		"time":     OpPass | OpDurationEncode,
+	},
+	"*time.Duration": {
+		"interval": OpDerefPass | OpDurationEncode,
+		"time":     OpDerefPass | OpDurationEncode,
+	},
+	"net.IP": {
+		"inet": OpPass | OpNetEncode,
+		"cidr": OpPass | OpNetEncode,
+	},
+	"*net.IP": {
+		"inet": OpDerefPass | OpNetEncode,
+		"cidr": OpDerefPass | OpNetEncode,
+	},
+	"[]net.IP": {
+		"inet[]": OpPass | OpNetEncode,
+		"cidr[]": OpPass | OpNetEncode,
+	},
+	"*[]net.IP": {
+		"inet[]": OpDerefPass | OpNetEncode,
+		"cidr[]": OpDerefPass | OpNetEncode,
+	},
+	"*net.IPNet": {
+		"inet": OpPass | OpNetEncode,
+		"cidr": OpPass | OpNetEncode,
+	},
+	"[]*net.IPNet": {
+		"inet[]": OpPass | OpNetEncode,
+		"cidr[]": OpPass | OpNetEncode,
+	},
+	"*[]*net.IPNet": {
+		"inet[]": OpDerefPass | OpNetEncode,
+		"cidr[]": OpDerefPass | OpNetEncode,
+	},
+	"netip.Addr": {
+		"inet": OpPass | OpNetEncode,
+		"cidr": OpPass | OpNetEncode,
+	},
+	"*netip.Addr": {
+		"inet": OpDerefPass | OpNetEncode,
+		"cidr": OpDerefPass | OpNetEncode,
+	},
+	"[]netip.Addr": {
+		"inet[]": OpPass | OpNetEncode,
+		"cidr[]": OpPass | OpNetEncode,
+	},
+	"*[]netip.Addr": {
+		"inet[]": OpDerefPass | OpNetEncode,
+		"cidr[]": OpDerefPass | OpNetEncode,
+	},
+	"netip.Prefix": {
+		"inet": OpPass | OpNetEncode,
+		"cidr": OpPass | OpNetEncode,
+	},
+	"*netip.Prefix": {
+		"inet": OpDerefPass | OpNetEncode,
+		"cidr": OpDerefPass | OpNetEncode,
+	},
+	"[]netip.Prefix": {
+		"inet[]": OpPass | OpNetEncode,
+		"cidr[]": OpPass | OpNetEncode,
+	},
+	"*[]netip.Prefix": {
+		"inet[]": OpDerefPass | OpNetEncode,
+		"cidr[]": OpDerefPass | OpNetEncode,
+	},
+	"net.HardwareAddr": {
+		"macaddr":  OpPass,
+		"macaddr8": OpPass,
+	},
+	"*net.HardwareAddr": {
+		"macaddr":  OpDerefPass,
+		"macaddr8": OpDerefPass,
+	},
+	"[]net.HardwareAddr": {
+		"macaddr[]":  OpPass,
+		"macaddr8[]": OpPass,
+	},
+	"*[]net.HardwareAddr": {
+		"macaddr[]":  OpDerefPass,
+		"macaddr8[]": OpDerefPass,
+	},
+	"pgtypes.InfinityTime": {
+		"date":        OpPass | OpInfinityEncode,
+		"timestamp":   OpPass | OpInfinityEncode,
+		"timestampTz": OpPass | OpInfinityEncode,
+	},
+	"*pgtypes.InfinityTime": {
+		"date":        OpDerefPass | OpInfinityEncode,
+		"timestamp":   OpDerefPass | OpInfinityEncode,
+		"timestampTz": OpDerefPass | OpInfinityEncode,
+	},
+	"pgtypes.TimeOfDay": {
+		"time":   OpPass | OpTimeOfDayEncode,
+		"timetz": OpPass | OpTimeOfDayEncode,
+	},
+	"*pgtypes.TimeOfDay": {
+		"time":   OpDerefPass | OpTimeOfDayEncode,
+		"timetz": OpDerefPass | OpTimeOfDayEncode,
+	},
+	"[]time.Duration": {
+		"interval[]": OpPass | OpDurationEncode,
+		"time[]":     OpPass | OpDurationEncode,
+	},
+	"*[]time.Duration": {
+		"interval[]": OpDerefPass | OpDurationEncode,
+		"time[]":     OpDerefPass | OpDurationEncode,
+	},
+	"[]pgtypes.TimeOfDay": {
+		"time[]":   OpPass | OpTimeOfDayEncode,
+		"timetz[]": OpPass | OpTimeOfDayEncode,
+	},
+	"*[]pgtypes.TimeOfDay": {
+		"time[]":   OpDerefPass | OpTimeOfDayEncode,
+		"timetz[]": OpDerefPass | OpTimeOfDayEncode,
+	},
+	"[]*big.Rat": {
+		"numeric[]": OpPass | OpNumericEncode,
+	},
+	"*[]*big.Rat": {
+		"numeric[]": OpDerefPass | OpNumericEncode,
+	},
 }
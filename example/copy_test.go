@@ -0,0 +1,86 @@
+package example
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCopyFromSQL(t *testing.T) {
+	sql, err := PointTable.CopyFromSQL("id", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `COPY "points" ("id", "x") FROM STDIN (FORMAT binary)`; sql != want {
+		t.Errorf("CopyFromSQL = %s; want %s", sql, want)
+	}
+	if _, err := PointTable.CopyFromSQL("nope"); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestCopyFrom(t *testing.T) {
+	rows := []Point{{u: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", X: []string{"a", "b"}}}
+	var b bytes.Buffer
+	if err := PointTable.CopyFrom(&b, rows, "id", "x"); err != nil {
+		t.Fatal(err)
+	}
+	out := b.Bytes()
+	if !bytes.HasPrefix(out, []byte("PGCOPY\n\xff\r\n\x00")) {
+		t.Errorf("missing COPY header: %q", out)
+	}
+	// each tuple starts with a field count of 2, and the trailer is -1
+	if !bytes.Contains(out, []byte{0, 2, 0, 0, 0, 16}) || !bytes.HasSuffix(out, []byte{0xff, 0xff}) {
+		t.Errorf("unexpected COPY stream: %q", out)
+	}
+}
+
+func TestCopyToSQL(t *testing.T) {
+	sql, err := PointTable.CopyToSQL("id", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `COPY "points" ("id", "x") TO STDOUT (FORMAT binary)`; sql != want {
+		t.Errorf("CopyToSQL = %s; want %s", sql, want)
+	}
+}
+
+func TestCopyRoundTrip(t *testing.T) {
+	colnames := []string{"id", "x", "ttl", "d"}
+	// dates are decoded as midnight in the location of the tz option
+	loc, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+	rows := []Point{
+		{u: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", X: []string{"a", "b"}, tt: 90 * time.Minute, d: time.Date(2020, 2, 29, 0, 0, 0, 0, loc)},
+		{u: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", X: []string{}, d: time.Date(1999, 12, 31, 0, 0, 0, 0, loc)},
+	}
+	var b bytes.Buffer
+	if err := PointTable.CopyFrom(&b, rows, colnames...); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := PointTable.CopyTo(&b, colnames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != len(rows) {
+		t.Fatalf("decoded %d rows; want %d", len(decoded), len(rows))
+	}
+	for i, v := range decoded {
+		want := rows[i]
+		if v.u != want.u || !reflect.DeepEqual(v.X, want.X) || v.tt != want.tt || !v.d.Equal(want.d) {
+			t.Errorf("row %d: decoded %+v; want %+v", i, v, want)
+		}
+	}
+
+	// the field count of each tuple must match the scanned columns
+	b.Reset()
+	if err := PointTable.CopyFrom(&b, rows, colnames...); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PointTable.CopyTo(&b, "id"); err == nil {
+		t.Error("expected an error for a mismatched field count")
+	}
+}
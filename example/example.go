@@ -1,19 +1,48 @@
 package example
 
 import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"time"
+
 	"github.com/satori/go.uuid"
 	"github.com/wdamron/pgx"
+	"github.com/wdamron/pgx-gen/pgtypes"
 )
 
 type Point struct {
-	X  []string           `pgx:"name:x;type:varchar[]"`
-	Y  *int64             `pgx:"name:y;type:int4"`
-	Z  *pgx.NullInt32     `pgx:"name:z;type:integer"`
-	H  *map[string]string `pgx:"name:h;type:hstore"`
-	H2 pgx.Hstore         `pgx:"name:h2;type:hstore"`
-	u  string             `pgx:"name:id;type:uuid"`
-	u2 *uuid.UUID         `pgx:"name:id2;type:uuid"`
-	j  *string            `pgx:"name:j;type:json"`
-	j2 map[string]int     `pgx:"name:j2;type:json"`
-	j3 []byte             `pgx:"name:j3;type:json"`
+	_  struct{}              `pgx:"table:points"`
+	X  []string              `pgx:"name:x;type:varchar[]"`
+	Y  *int64                `pgx:"name:y;type:int4;default:0"`
+	Z  *pgx.NullInt32        `pgx:"name:z;type:integer"`
+	H  *map[string]string    `pgx:"name:h;type:hstore"`
+	H2 pgx.Hstore            `pgx:"name:h2;type:hstore;merge:coalesce"`
+	u  string                `pgx:"name:id;type:uuid;pk"`
+	u2 *uuid.UUID            `pgx:"name:id2;type:uuid;unique;merge:keep"`
+	j  *string               `pgx:"name:j;type:json"`
+	j2 map[string]int        `pgx:"name:j2;type:json;json:strict"`
+	j3 []byte                `pgx:"name:j3;type:json"`
+	n  sql.NullString        `pgx:"name:n;type:text"`
+	p  *big.Rat              `pgx:"name:p;type:numeric(12,2)"`
+	jb json.RawMessage       `pgx:"name:jb;type:jsonb"`
+	us []uuid.UUID           `pgx:"name:us;type:uuid[]"`
+	ja []map[string]int      `pgx:"name:ja;type:jsonb[]"`
+	ps []string              `pgx:"name:ps;type:numeric(8,3)[]"`
+	xs []*int32              `pgx:"name:xs;type:int4[]"`
+	ts []pgx.NullTime        `pgx:"name:ts;type:timestampTz[]"`
+	m  [][]float64           `pgx:"name:m;type:float8[][]"`
+	tt time.Duration         `pgx:"name:ttl;type:interval"`
+	bp *pgtypes.Interval     `pgx:"name:bp;type:interval"`
+	st time.Duration         `pgx:"name:st;type:time without time zone"`
+	tz pgtypes.TimeOfDay     `pgx:"name:tz;type:timetz"`
+	d  time.Time             `pgx:"name:d;type:date;tz:Europe/Paris"`
+	lt time.Time             `pgx:"name:lt;type:timestamp;tz:America/New_York"`
+	vu *pgtypes.InfinityTime `pgx:"name:vu;type:timestampTz"`
+	ip net.IP                `pgx:"name:ip;type:inet"`
+	nw *net.IPNet            `pgx:"name:nw;type:cidr"`
+	na []netip.Addr          `pgx:"name:addrs;type:inet[]"`
+	hw net.HardwareAddr      `pgx:"name:hw;type:macaddr"`
 }
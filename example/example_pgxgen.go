@@ -3,9 +3,16 @@ package example
 // Generated by pgxgen (see example.go)
 
 import (
+	"database/sql"
 	"encoding/hex"
 	"errors"
+	"io"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
 
+	"github.com/satori/go.uuid"
 	"github.com/wdamron/pgx"
 	"github.com/wdamron/pgx-gen/pgtypes"
 )
@@ -15,41 +22,60 @@ import (
 type PointTableType struct {
 	// UnboundEncoders are used by PointParamsEncoder.Bind to bind query/statement
 	// parameters from a value of type Point
-	UnboundEncoders [10]func(*Point) pgx.Encoder
+	UnboundEncoders [30]func(*Point) pgx.Encoder
 	// UnboundScanners are used by PointParamsScanner.Bind to bind query/statement
 	// results to fields within type Point
-	UnboundScanners [10]func(*Point) pgx.Scanner
+	UnboundScanners [30]func(*Point) pgx.Scanner
 	// Names contains an ordered list of column names
-	Names [10]string
+	Names [30]string
 	// Types contains an ordered list of column types
-	Types [10]string
+	Types [30]string
 	// Aliases contains an ordered list of column names aliased as hex-encoded
 	// indexes, for faster look-ups during decoding
-	Aliases [10]string
+	Aliases [30]string
 	// Formats contains an ordered list of column format codes (text=0, binary=1)
-	Formats [10]int
+	Formats [30]int
 	// Oids contains an ordered list of column oid codes (corresponding with
 	// Postgres types)
-	Oids [10]pgx.Oid
+	Oids [30]pgx.Oid
+	// Merges contains an ordered list of column merge rules for upserts
+	// (overwrite, keep, coalesce or skip)
+	Merges [30]string
+	// TableName is the name of the table, as used when generating SQL
+	TableName string
+	// PrimaryKey contains an ordered list of primary key column names
+	PrimaryKey []string
+	// ConflictTarget contains an ordered list of column names used as the ON
+	// CONFLICT target for upserts
+	ConflictTarget []string
 }
 
 // PointTable describes the table corresponding with type Point
 var PointTable = PointTableType{
-	UnboundEncoders: [10]func(*Point) pgx.Encoder{
+	UnboundEncoders: [30]func(*Point) pgx.Encoder{
 		// Encode v.X as varchar[]
 		func(v *Point) pgx.Encoder {
 			return pgtypes.VarcharArrayEncoder(v.X)
 		},
 		// Encode v.Y as int4
 		func(v *Point) pgx.Encoder {
+			if v.Y == nil {
+				return pgtypes.NullEncoder(pgtypes.Int4Oid, 1)
+			}
 			return pgtypes.Int4Encoder(int32(*v.Y))
 		},
 		// Encode v.Z as int4
 		func(v *Point) pgx.Encoder {
+			if v.Z == nil {
+				return pgtypes.NullEncoder(pgtypes.Int4Oid, 1)
+			}
 			return v.Z
 		},
 		// Encode v.H as hstore
 		func(v *Point) pgx.Encoder {
+			if v.H == nil {
+				return pgtypes.NullEncoder(pgtypes.HstoreOid, 1)
+			}
 			return pgtypes.HstoreMapEncoder(*v.H)
 		},
 		// Encode v.H2 as hstore
@@ -62,37 +88,147 @@ var PointTable = PointTableType{
 		},
 		// Encode v.u2 as uuid
 		func(v *Point) pgx.Encoder {
+			if v.u2 == nil {
+				return pgtypes.NullEncoder(pgtypes.UUIDOid, 1)
+			}
 			return pgtypes.UUIDEncoder(*v.u2)
 		},
 		// Encode v.j as json
 		func(v *Point) pgx.Encoder {
+			if v.j == nil {
+				return pgtypes.NullEncoder(pgtypes.JSONOid, 0)
+			}
 			return pgtypes.JSONEncoderString(*v.j)
 		},
 		// Encode v.j2 as json
 		func(v *Point) pgx.Encoder {
-			return pgtypes.JSONEncoder(v.j2)
+			return pgtypes.JSONEncoderCodec(v.j2, "strict")
 		},
 		// Encode v.j3 as json
 		func(v *Point) pgx.Encoder {
 			return pgtypes.JSONEncoderBytes(v.j3)
 		},
+		// Encode v.n as text
+		func(v *Point) pgx.Encoder {
+			if !v.n.Valid {
+				return pgtypes.NullEncoder(pgtypes.TextOid, 0)
+			}
+			return pgtypes.TextEncoder(v.n.String)
+		},
+		// Encode v.p as numeric
+		func(v *Point) pgx.Encoder {
+			if v.p == nil {
+				return pgtypes.NullEncoder(pgtypes.NumericOid, 1)
+			}
+			return pgtypes.NumericEncoderRat(v.p, 12, 2)
+		},
+		// Encode v.jb as jsonb
+		func(v *Point) pgx.Encoder {
+			return pgtypes.JSONBEncoderBytes(v.jb)
+		},
+		// Encode v.us as uuid[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.UUIDArrayEncoder(v.us)
+		},
+		// Encode v.ja as jsonb[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.JSONBArrayEncoder(v.ja)
+		},
+		// Encode v.ps as numeric[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.NumericArrayEncoderString(v.ps, 8, 3)
+		},
+		// Encode v.xs as int4[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.Int4ArrayEncoderPtr(v.xs)
+		},
+		// Encode v.ts as timestampTz[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.TimestampTzArrayEncoderNull(v.ts)
+		},
+		// Encode v.m as float[][]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.Float8ArrayEncoderMulti(v.m)
+		},
+		// Encode v.tt as interval
+		func(v *Point) pgx.Encoder {
+			return pgtypes.IntervalEncoderDuration(v.tt)
+		},
+		// Encode v.bp as interval
+		func(v *Point) pgx.Encoder {
+			if v.bp == nil {
+				return pgtypes.NullEncoder(pgtypes.IntervalOid, 1)
+			}
+			return pgtypes.IntervalEncoder(*v.bp)
+		},
+		// Encode v.st as time
+		func(v *Point) pgx.Encoder {
+			return pgtypes.TimeEncoderDuration(v.st)
+		},
+		// Encode v.tz as timetz
+		func(v *Point) pgx.Encoder {
+			return pgtypes.TimeTzEncoderTimeOfDay(v.tz)
+		},
+		// Encode v.d as date
+		func(v *Point) pgx.Encoder {
+			return pgtypes.DateEncoder(v.d)
+		},
+		// Encode v.lt as timestamp
+		func(v *Point) pgx.Encoder {
+			return pgtypes.TimestampEncoderLocation(v.lt, "America/New_York")
+		},
+		// Encode v.vu as timestampTz
+		func(v *Point) pgx.Encoder {
+			if v.vu == nil {
+				return pgtypes.NullEncoder(pgtypes.TimestampTzOid, 1)
+			}
+			return pgtypes.TimestampTzEncoderInfinity(*v.vu)
+		},
+		// Encode v.ip as inet
+		func(v *Point) pgx.Encoder {
+			return pgtypes.InetEncoderIP(v.ip)
+		},
+		// Encode v.nw as cidr
+		func(v *Point) pgx.Encoder {
+			if v.nw == nil {
+				return pgtypes.NullEncoder(pgtypes.CidrOid, 1)
+			}
+			return pgtypes.CidrEncoderIPNet(v.nw)
+		},
+		// Encode v.na as inet[]
+		func(v *Point) pgx.Encoder {
+			return pgtypes.InetArrayEncoderAddr(v.na)
+		},
+		// Encode v.hw as macaddr
+		func(v *Point) pgx.Encoder {
+			return pgtypes.MacaddrEncoder(v.hw)
+		},
 	},
-	UnboundScanners: [10]func(*Point) pgx.Scanner{
+	UnboundScanners: [30]func(*Point) pgx.Scanner{
 		// Decode column x::varchar[] into v.X
 		func(v *Point) pgx.Scanner {
 			return pgtypes.VarcharArrayScanner(&v.X)
 		},
 		// Decode column y::int4 into v.Y
 		func(v *Point) pgx.Scanner {
-			return pgtypes.IntoInt64(v.Y)
+			return pgtypes.NullableScanner(func() { v.Y = nil }, func() pgx.Scanner {
+				v.Y = new(int64)
+				return pgtypes.IntoInt64(v.Y)
+			})
 		},
 		// Decode column z::int4 into v.Z
 		func(v *Point) pgx.Scanner {
-			return v.Z
+			return pgtypes.NullableScanner(func() { v.Z = nil }, func() pgx.Scanner {
+				v.Z = new(pgx.NullInt32)
+				return v.Z
+			})
 		},
 		// Decode column h::hstore into v.H
 		func(v *Point) pgx.Scanner {
-			return pgtypes.HstoreMapScanner(v.H)
+			return pgtypes.NullableScanner(func() { v.H = nil }, func() pgx.Scanner {
+				v.H = new(map[string]string)
+				return pgtypes.HstoreMapScanner(v.H)
+			})
 		},
 		// Decode column h2::hstore into v.H2
 		func(v *Point) pgx.Scanner {
@@ -104,22 +240,123 @@ var PointTable = PointTableType{
 		},
 		// Decode column id2::uuid into v.u2
 		func(v *Point) pgx.Scanner {
-			return pgtypes.UUIDScanner(v.u2)
+			return pgtypes.NullableScanner(func() { v.u2 = nil }, func() pgx.Scanner {
+				v.u2 = new(uuid.UUID)
+				return pgtypes.UUIDScanner(v.u2)
+			})
 		},
 		// Decode column j::json into v.j
 		func(v *Point) pgx.Scanner {
-			return pgtypes.JSONScannerString(v.j)
+			return pgtypes.NullableScanner(func() { v.j = nil }, func() pgx.Scanner {
+				v.j = new(string)
+				return pgtypes.JSONScannerString(v.j)
+			})
 		},
 		// Decode column j2::json into v.j2
 		func(v *Point) pgx.Scanner {
-			return pgtypes.JSONScanner(&v.j2)
+			return pgtypes.JSONScannerCodec(&v.j2, "strict")
 		},
 		// Decode column j3::json into v.j3
 		func(v *Point) pgx.Scanner {
 			return pgtypes.JSONScannerBytes(&v.j3)
 		},
+		// Decode column n::text into v.n
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NullableScanner(func() { v.n = sql.NullString{} }, func() pgx.Scanner {
+				v.n.Valid = true
+				return pgtypes.TextScanner(&v.n.String)
+			})
+		},
+		// Decode column p::numeric into v.p
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NullableScanner(func() { v.p = nil }, func() pgx.Scanner {
+				v.p = new(big.Rat)
+				return pgtypes.NumericScannerRat(v.p)
+			})
+		},
+		// Decode column jb::jsonb into v.jb
+		func(v *Point) pgx.Scanner {
+			return pgtypes.JSONBScannerBytes((*[]byte)(&v.jb))
+		},
+		// Decode column us::uuid[] into v.us
+		func(v *Point) pgx.Scanner {
+			return pgtypes.UUIDArrayScanner(&v.us)
+		},
+		// Decode column ja::jsonb[] into v.ja
+		func(v *Point) pgx.Scanner {
+			return pgtypes.JSONBArrayScanner(&v.ja)
+		},
+		// Decode column ps::numeric[] into v.ps
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NumericArrayScannerString(&v.ps)
+		},
+		// Decode column xs::int4[] into v.xs
+		func(v *Point) pgx.Scanner {
+			return pgtypes.Int4ArrayScannerPtr(&v.xs)
+		},
+		// Decode column ts::timestampTz[] into v.ts
+		func(v *Point) pgx.Scanner {
+			return pgtypes.TimestampTzArrayScannerNull(&v.ts)
+		},
+		// Decode column m::float[][] into v.m
+		func(v *Point) pgx.Scanner {
+			return pgtypes.Float8ArrayScannerMulti(&v.m)
+		},
+		// Decode column ttl::interval into v.tt
+		func(v *Point) pgx.Scanner {
+			return pgtypes.IntervalScannerDuration(&v.tt)
+		},
+		// Decode column bp::interval into v.bp
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NullableScanner(func() { v.bp = nil }, func() pgx.Scanner {
+				v.bp = new(pgtypes.Interval)
+				return pgtypes.IntervalScanner(v.bp)
+			})
+		},
+		// Decode column st::time into v.st
+		func(v *Point) pgx.Scanner {
+			return pgtypes.TimeScannerDuration(&v.st)
+		},
+		// Decode column tz::timetz into v.tz
+		func(v *Point) pgx.Scanner {
+			return pgtypes.TimeTzScannerTimeOfDay(&v.tz)
+		},
+		// Decode column d::date into v.d
+		func(v *Point) pgx.Scanner {
+			return pgtypes.DateScannerLocation(&v.d, "Europe/Paris")
+		},
+		// Decode column lt::timestamp into v.lt
+		func(v *Point) pgx.Scanner {
+			return pgtypes.TimestampScannerLocation(&v.lt, "America/New_York")
+		},
+		// Decode column vu::timestampTz into v.vu
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NullableScanner(func() { v.vu = nil }, func() pgx.Scanner {
+				v.vu = new(pgtypes.InfinityTime)
+				return pgtypes.TimestampTzScannerInfinity(v.vu)
+			})
+		},
+		// Decode column ip::inet into v.ip
+		func(v *Point) pgx.Scanner {
+			return pgtypes.InetScannerIP(&v.ip)
+		},
+		// Decode column nw::cidr into v.nw
+		func(v *Point) pgx.Scanner {
+			return pgtypes.NullableScanner(func() { v.nw = nil }, func() pgx.Scanner {
+				v.nw = new(net.IPNet)
+				return pgtypes.CidrScannerIPNet(v.nw)
+			})
+		},
+		// Decode column addrs::inet[] into v.na
+		func(v *Point) pgx.Scanner {
+			return pgtypes.InetArrayScannerAddr(&v.na)
+		},
+		// Decode column hw::macaddr into v.hw
+		func(v *Point) pgx.Scanner {
+			return pgtypes.MacaddrScanner(&v.hw)
+		},
 	},
-	Names: [10]string{
+	Names: [30]string{
 		"x",
 		"y",
 		"z",
@@ -130,8 +367,28 @@ var PointTable = PointTableType{
 		"j",
 		"j2",
 		"j3",
+		"n",
+		"p",
+		"jb",
+		"us",
+		"ja",
+		"ps",
+		"xs",
+		"ts",
+		"m",
+		"ttl",
+		"bp",
+		"st",
+		"tz",
+		"d",
+		"lt",
+		"vu",
+		"ip",
+		"nw",
+		"addrs",
+		"hw",
 	},
-	Types: [10]string{
+	Types: [30]string{
 		"varchar[]",
 		"int4",
 		"int4",
@@ -142,23 +399,63 @@ var PointTable = PointTableType{
 		"json",
 		"json",
 		"json",
+		"text",
+		"numeric",
+		"jsonb",
+		"uuid[]",
+		"jsonb[]",
+		"numeric[]",
+		"int4[]",
+		"timestampTz[]",
+		"float[][]",
+		"interval",
+		"interval",
+		"time",
+		"timetz",
+		"date",
+		"timestamp",
+		"timestampTz",
+		"inet",
+		"cidr",
+		"inet[]",
+		"macaddr",
 	},
 	// Aliases contains an ordered list of column names aliased as hex-encoded
 	// indexes, for faster look-ups during decoding
-	Aliases: [10]string{
-		"x as __00::varchar[]",
-		"y as __01::int4",
-		"z as __02::int4",
-		"h as __03::hstore",
-		"h2 as __04::hstore",
-		"id as __05::uuid",
-		"id2 as __06::uuid",
-		"j as __07::json",
-		"j2 as __08::json",
-		"j3 as __09::json",
+	Aliases: [30]string{
+		`"x"::varchar[] AS __00`,
+		`"y"::int4 AS __01`,
+		`"z"::int4 AS __02`,
+		`"h"::hstore AS __03`,
+		`"h2"::hstore AS __04`,
+		`"id"::uuid AS __05`,
+		`"id2"::uuid AS __06`,
+		`"j"::json AS __07`,
+		`"j2"::json AS __08`,
+		`"j3"::json AS __09`,
+		`"n"::text AS __0a`,
+		`"p"::numeric AS __0b`,
+		`"jb"::jsonb AS __0c`,
+		`"us"::uuid[] AS __0d`,
+		`"ja"::jsonb[] AS __0e`,
+		`"ps"::numeric[] AS __0f`,
+		`"xs"::int4[] AS __10`,
+		`"ts"::timestampTz[] AS __11`,
+		`"m"::float[][] AS __12`,
+		`"ttl"::interval AS __13`,
+		`"bp"::interval AS __14`,
+		`"st"::time AS __15`,
+		`"tz"::timetz AS __16`,
+		`"d"::date AS __17`,
+		`"lt"::timestamp AS __18`,
+		`"vu"::timestampTz AS __19`,
+		`"ip"::inet AS __1a`,
+		`"nw"::cidr AS __1b`,
+		`"addrs"::inet[] AS __1c`,
+		`"hw"::macaddr AS __1d`,
 	},
-	Formats: [10]int{1, 1, 1, 1, 1, 1, 1, 0, 0, 0},
-	Oids: [10]pgx.Oid{
+	Formats: [30]int{1, 1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	Oids: [30]pgx.Oid{
 		pgtypes.VarcharArrayOid,
 		pgtypes.Int4Oid,
 		pgtypes.Int4Oid,
@@ -169,6 +466,34 @@ var PointTable = PointTableType{
 		pgtypes.JSONOid,
 		pgtypes.JSONOid,
 		pgtypes.JSONOid,
+		pgtypes.TextOid,
+		pgtypes.NumericOid,
+		pgtypes.JSONBOid,
+		pgtypes.UUIDArrayOid,
+		pgtypes.JSONBArrayOid,
+		pgtypes.NumericArrayOid,
+		pgtypes.Int4ArrayOid,
+		pgtypes.TimestampTzArrayOid,
+		pgtypes.Float8ArrayOid,
+		pgtypes.IntervalOid,
+		pgtypes.IntervalOid,
+		pgtypes.TimeOid,
+		pgtypes.TimeTzOid,
+		pgtypes.DateOid,
+		pgtypes.TimestampOid,
+		pgtypes.TimestampTzOid,
+		pgtypes.InetOid,
+		pgtypes.CidrOid,
+		pgtypes.InetArrayOid,
+		pgtypes.MacaddrOid,
+	},
+	Merges:    [30]string{"overwrite", "overwrite", "overwrite", "overwrite", "coalesce", "overwrite", "keep", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite", "overwrite"},
+	TableName: "points",
+	PrimaryKey: []string{
+		"id",
+	},
+	ConflictTarget: []string{
+		"id",
 	},
 }
 
@@ -197,6 +522,46 @@ func (t *PointTableType) Index(colname string) int {
 		return 8
 	case "j3":
 		return 9
+	case "n":
+		return 10
+	case "p":
+		return 11
+	case "jb":
+		return 12
+	case "us":
+		return 13
+	case "ja":
+		return 14
+	case "ps":
+		return 15
+	case "xs":
+		return 16
+	case "ts":
+		return 17
+	case "m":
+		return 18
+	case "ttl":
+		return 19
+	case "bp":
+		return 20
+	case "st":
+		return 21
+	case "tz":
+		return 22
+	case "d":
+		return 23
+	case "lt":
+		return 24
+	case "vu":
+		return 25
+	case "ip":
+		return 26
+	case "nw":
+		return 27
+	case "addrs":
+		return 28
+	case "hw":
+		return 29
 	}
 	return -1
 }
@@ -227,7 +592,7 @@ func (t *PointTableType) Alias(colnames ...string) ([]string, error) {
 	aliases := []string{}
 	// If no columns are specified, alias all columns:
 	if len(colnames) == 0 {
-		return PointTable.Aliases[:10], nil
+		return PointTable.Aliases[:30], nil
 	}
 	indexes, err := PointTable.Indexes(colnames...)
 	if err != nil {
@@ -242,7 +607,7 @@ func (t *PointTableType) Alias(colnames ...string) ([]string, error) {
 // AliasAll aliases column names as hex-encoded indexes, for faster look-ups
 // during decoding
 func (t *PointTableType) AliasAll() string {
-	return "x as __00::varchar[], y as __01::int4, z as __02::int4, h as __03::hstore, h2 as __04::hstore, id as __05::uuid, id2 as __06::uuid, j as __07::json, j2 as __08::json, j3 as __09::json"
+	return `"x"::varchar[] AS __00, "y"::int4 AS __01, "z"::int4 AS __02, "h"::hstore AS __03, "h2"::hstore AS __04, "id"::uuid AS __05, "id2"::uuid AS __06, "j"::json AS __07, "j2"::json AS __08, "j3"::json AS __09, "n"::text AS __0a, "p"::numeric AS __0b, "jb"::jsonb AS __0c, "us"::uuid[] AS __0d, "ja"::jsonb[] AS __0e, "ps"::numeric[] AS __0f, "xs"::int4[] AS __10, "ts"::timestampTz[] AS __11, "m"::float[][] AS __12, "ttl"::interval AS __13, "bp"::interval AS __14, "st"::time AS __15, "tz"::timetz AS __16, "d"::date AS __17, "lt"::timestamp AS __18, "vu"::timestampTz AS __19, "ip"::inet AS __1a, "nw"::cidr AS __1b, "addrs"::inet[] AS __1c, "hw"::macaddr AS __1d`
 }
 
 // DecodeRow decodes a single row/result from r into v.
@@ -323,6 +688,498 @@ func (fe PointFieldEncoders) Bind(v *Point) ([]pgx.Encoder, error) {
 	return bound, nil
 }
 
+// Statement binds query/statement parameter encoders for v, returning them
+// along with sql and the oids and format codes of the parameters.
+//
+// Parameters are bound positionally, in correspondence with the field indexes
+// stored within the PointFieldEncoders slice.
+func (fe PointFieldEncoders) Statement(sql string, v *Point) (*pgtypes.Statement, error) {
+	args, err := fe.Bind(v)
+	if err != nil {
+		return nil, err
+	}
+	st := &pgtypes.Statement{
+		SQL:     sql,
+		Oids:    make([]pgx.Oid, len(fe)),
+		Formats: make([]int, len(fe)),
+		Args:    args,
+	}
+	for i, index := range fe {
+		st.Oids[i] = PointTable.Oids[index]
+		st.Formats[i] = PointTable.Formats[index]
+	}
+	return st, nil
+}
+
+// CreateTableSQL returns a CREATE TABLE statement for PointTable.
+func (t *PointTableType) CreateTableSQL() string {
+	return `CREATE TABLE "points" (
+	"x" varchar[] NOT NULL,
+	"y" int4 DEFAULT 0,
+	"z" int4,
+	"h" hstore,
+	"h2" hstore NOT NULL,
+	"id" uuid NOT NULL,
+	"id2" uuid,
+	"j" json,
+	"j2" json NOT NULL,
+	"j3" json NOT NULL,
+	"n" text,
+	"p" numeric(12,2),
+	"jb" jsonb NOT NULL,
+	"us" uuid[] NOT NULL,
+	"ja" jsonb[] NOT NULL,
+	"ps" numeric(8,3)[] NOT NULL,
+	"xs" int4[] NOT NULL,
+	"ts" timestampTz[] NOT NULL,
+	"m" float[][] NOT NULL,
+	"ttl" interval NOT NULL,
+	"bp" interval,
+	"st" time NOT NULL,
+	"tz" timetz NOT NULL,
+	"d" date NOT NULL,
+	"lt" timestamp NOT NULL,
+	"vu" timestampTz,
+	"ip" inet NOT NULL,
+	"nw" cidr,
+	"addrs" inet[] NOT NULL,
+	"hw" macaddr NOT NULL,
+	PRIMARY KEY ("id"),
+	UNIQUE ("id2")
+)`
+}
+
+// InsertSQL returns an INSERT statement for the columns in PointTable named by
+// colnames, with parameter placeholders in the same order as colnames.
+//
+// If no column names are provided, all columns will be inserted.
+func (t *PointTableType) InsertSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	params := make([]string, len(colnames))
+	for i := range colnames {
+		params[i] = "$" + strconv.Itoa(i+1)
+	}
+	return "INSERT INTO " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") VALUES (" + strings.Join(params, ", ") + ")", nil
+}
+
+// InsertArgs returns an INSERT statement for the columns named by colnames,
+// along with parameter oids, format codes and encoders bound to v.
+//
+// If no column names are provided, all columns will be inserted.
+func (v *Point) InsertArgs(colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = PointTable.Names[:]
+	}
+	sql, err := PointTable.InsertSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := PointTable.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+// ReturningSQL returns a RETURNING clause for the columns in PointTable named
+// by colnames.
+//
+// Returned columns are aliased (see Alias), so results may be decoded quickly
+// with Point.DecodeRow. If no column names are provided, all columns will be
+// returned.
+func (t *PointTableType) ReturningSQL(colnames ...string) (string, error) {
+	aliases, err := t.Alias(colnames...)
+	if err != nil {
+		return "", err
+	}
+	return "RETURNING " + strings.Join(aliases, ", "), nil
+}
+
+// InsertReturning is like InsertArgs, with a RETURNING clause for the columns
+// named by returning appended to the statement.
+//
+// Pass the statement to Point.QueryReturning to write the returned values back
+// into v. If no returned column names are provided, all columns will be
+// returned.
+func (v *Point) InsertReturning(returning []string, colnames ...string) (*pgtypes.Statement, error) {
+	ret, err := PointTable.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	st, err := v.InsertArgs(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	st.SQL += " " + ret
+	return st, nil
+}
+
+// QueryReturning executes st with q, decoding the first row of the results
+// into v.
+//
+// The statement should end with a RETURNING clause for aliased columns (see
+// PointTable.ReturningSQL). If no rows are returned, pgx.ErrNoRows is
+// returned.
+func (v *Point) QueryReturning(q pgtypes.Querier, st *pgtypes.Statement) error {
+	rows, err := q.Query(st.SQL, st.Params()...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+	if err = v.DecodeRow(rows); err != nil {
+		return err
+	}
+	rows.Close()
+	return rows.Err()
+}
+
+// BatchInsert returns multi-row INSERT statements for the columns in
+// PointTable named by colnames, along with parameter oids, format codes and
+// encoders bound to each of rows.
+//
+// Rows are split across as many statements as needed to keep each statement
+// within the bind parameter limit (see pgtypes.MaxParams). If no column names
+// are provided, all columns will be inserted.
+func (t *PointTableType) BatchInsert(rows []Point, colnames ...string) ([]*pgtypes.Statement, error) {
+	return t.batchInsert(rows, "", colnames)
+}
+
+// BatchInsertReturning is like BatchInsert, with a RETURNING clause for the
+// columns named by returning appended to each statement.
+//
+// Returned columns are aliased (see Alias), so results may be decoded quickly
+// with Point.DecodeRow. If no returned column names are provided, all columns
+// will be returned.
+func (t *PointTableType) BatchInsertReturning(rows []Point, returning []string, colnames ...string) ([]*pgtypes.Statement, error) {
+	ret, err := t.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	return t.batchInsert(rows, " "+ret, colnames)
+}
+
+func (t *PointTableType) batchInsert(rows []Point, suffix string, colnames []string) ([]*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	prefix := "INSERT INTO " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") VALUES "
+	chunkSize := pgtypes.MaxParams / len(fe)
+	stmts := make([]*pgtypes.Statement, 0, (len(rows)+chunkSize-1)/chunkSize)
+	params := make([]string, len(fe))
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		n := (end - start) * len(fe)
+		st := &pgtypes.Statement{
+			Oids:    make([]pgx.Oid, 0, n),
+			Formats: make([]int, 0, n),
+			Args:    make([]pgx.Encoder, 0, n),
+		}
+		values := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			args, err := fe.Bind(&rows[i])
+			if err != nil {
+				return nil, err
+			}
+			for j, index := range fe {
+				params[j] = "$" + strconv.Itoa(len(st.Args)+1)
+				st.Oids = append(st.Oids, t.Oids[index])
+				st.Formats = append(st.Formats, t.Formats[index])
+				st.Args = append(st.Args, args[j])
+			}
+			values = append(values, "("+strings.Join(params, ", ")+")")
+		}
+		st.SQL = prefix + strings.Join(values, ", ") + suffix
+		stmts = append(stmts, st)
+	}
+	return stmts, nil
+}
+
+// WherePK returns a WHERE clause matching the primary key columns of
+// PointTable.
+//
+// Parameter placeholders are numbered from offset+1, in the order of
+// PrimaryKey.
+func (t *PointTableType) WherePK(offset int) string {
+	conds := make([]string, len(t.PrimaryKey))
+	for i, colname := range t.PrimaryKey {
+		conds[i] = pgtypes.QuoteIdentifier(colname) + " = $" + strconv.Itoa(offset+i+1)
+	}
+	return "WHERE " + strings.Join(conds, " AND ")
+}
+
+// UpdateByPKSQL returns an UPDATE statement which sets the columns in
+// PointTable named by colnames, for the row matching the primary key.
+//
+// Parameters for the updated columns come first, followed by the primary key
+// columns. If no column names are provided, all columns outside of the primary
+// key will be updated.
+func (t *PointTableType) UpdateByPKSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = []string{"x", "y", "z", "h", "h2", "id2", "j", "j2", "j3", "n", "p", "jb", "us", "ja", "ps", "xs", "ts", "m", "ttl", "bp", "st", "tz", "d", "lt", "vu", "ip", "nw", "addrs", "hw"}
+	}
+	if len(colnames) == 0 {
+		return "", errors.New("no columns to update in PointTable")
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	sets := make([]string, len(colnames))
+	for i, colname := range colnames {
+		sets[i] = pgtypes.QuoteIdentifier(colname) + " = $" + strconv.Itoa(i+1)
+	}
+	return "UPDATE " + pgtypes.QuoteTableName(t.TableName) + " SET " + strings.Join(sets, ", ") + " " + t.WherePK(len(colnames)), nil
+}
+
+// UpdateByPK returns an UPDATE statement for the columns named by colnames,
+// along with parameter oids, format codes and encoders bound to v.
+//
+// The row to update is matched by the primary key fields of v. If no column
+// names are provided, all columns outside of the primary key will be updated.
+func (t *PointTableType) UpdateByPK(v *Point, colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = []string{"x", "y", "z", "h", "h2", "id2", "j", "j2", "j3", "n", "p", "jb", "us", "ja", "ps", "xs", "ts", "m", "ttl", "bp", "st", "tz", "d", "lt", "vu", "ip", "nw", "addrs", "hw"}
+	}
+	sql, err := t.UpdateByPKSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(append(colnames[:len(colnames):len(colnames)], t.PrimaryKey...)...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+// UpdateByPKReturning is like UpdateByPK, with a RETURNING clause for the
+// columns named by returning appended to the statement.
+//
+// Pass the statement to Point.QueryReturning to write the returned values back
+// into v. If no returned column names are provided, all columns will be
+// returned.
+func (t *PointTableType) UpdateByPKReturning(v *Point, returning []string, colnames ...string) (*pgtypes.Statement, error) {
+	ret, err := t.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	st, err := t.UpdateByPK(v, colnames...)
+	if err != nil {
+		return nil, err
+	}
+	st.SQL += " " + ret
+	return st, nil
+}
+
+// DeleteByPKSQL returns a DELETE statement for the row in PointTable matching
+// the primary key.
+func (t *PointTableType) DeleteByPKSQL() string {
+	return "DELETE FROM " + pgtypes.QuoteTableName(t.TableName) + " " + t.WherePK(0)
+}
+
+// DeleteByPK returns a DELETE statement along with parameter oids, format
+// codes and encoders bound to the primary key fields of v.
+func (t *PointTableType) DeleteByPK(v *Point) (*pgtypes.Statement, error) {
+	fe, err := t.Encoders(t.PrimaryKey...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(t.DeleteByPKSQL(), v)
+}
+
+// SelectByPKSQL returns a SELECT statement for the columns in PointTable named
+// by colnames, for the row matching the primary key.
+//
+// Selected columns are aliased (see Alias), so results may be decoded quickly
+// with Point.DecodeRow. If no column names are provided, all columns will be
+// selected.
+func (t *PointTableType) SelectByPKSQL(colnames ...string) (string, error) {
+	aliases, err := t.Alias(colnames...)
+	if err != nil {
+		return "", err
+	}
+	return "SELECT " + strings.Join(aliases, ", ") + " FROM " + pgtypes.QuoteTableName(t.TableName) + " " + t.WherePK(0), nil
+}
+
+// SelectByPK returns a SELECT statement for the columns named by colnames,
+// along with parameter oids, format codes and encoders bound to the primary
+// key fields of v.
+//
+// If no column names are provided, all columns will be selected.
+func (t *PointTableType) SelectByPK(v *Point, colnames ...string) (*pgtypes.Statement, error) {
+	sql, err := t.SelectByPKSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(t.PrimaryKey...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+// UpsertSQL returns an INSERT ... ON CONFLICT statement for the columns in
+// PointTable named by colnames, with parameter placeholders in the same order
+// as colnames.
+//
+// Conflicts are detected on the ConflictTarget columns. Other columns are
+// updated according to their merge rules (see Merges); if no columns are to be
+// updated, conflicting rows are left as they are. If no column names are
+// provided, all columns without the skip merge rule will be upserted.
+func (t *PointTableType) UpsertSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = []string{"x", "y", "z", "h", "h2", "id", "id2", "j", "j2", "j3", "n", "p", "jb", "us", "ja", "ps", "xs", "ts", "m", "ttl", "bp", "st", "tz", "d", "lt", "vu", "ip", "nw", "addrs", "hw"}
+	}
+	sql, err := t.InsertSQL(colnames...)
+	if err != nil {
+		return "", err
+	}
+	indexes, err := t.Indexes(colnames...)
+	if err != nil {
+		return "", err
+	}
+	sets := []string{}
+	for _, index := range indexes {
+		colname := t.Names[index]
+		ident := pgtypes.QuoteIdentifier(colname)
+		isTarget := false
+		for _, target := range t.ConflictTarget {
+			if target == colname {
+				isTarget = true
+				break
+			}
+		}
+		if isTarget {
+			continue
+		}
+		switch t.Merges[index] {
+		case "keep", "skip":
+		case "coalesce":
+			sets = append(sets, ident+" = COALESCE(EXCLUDED."+ident+", "+pgtypes.QuoteTableName(t.TableName)+"."+ident+")")
+		default:
+			sets = append(sets, ident+" = EXCLUDED."+ident)
+		}
+	}
+	sql += " ON CONFLICT (" + strings.Join(pgtypes.QuoteIdentifiers(t.ConflictTarget), ", ") + ")"
+	if len(sets) == 0 {
+		return sql + " DO NOTHING", nil
+	}
+	return sql + " DO UPDATE SET " + strings.Join(sets, ", "), nil
+}
+
+// Upsert returns an INSERT ... ON CONFLICT statement for the columns named by
+// colnames, along with parameter oids, format codes and encoders bound to v.
+//
+// If no column names are provided, all columns without the skip merge rule
+// will be upserted.
+func (t *PointTableType) Upsert(v *Point, colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = []string{"x", "y", "z", "h", "h2", "id", "id2", "j", "j2", "j3", "n", "p", "jb", "us", "ja", "ps", "xs", "ts", "m", "ttl", "bp", "st", "tz", "d", "lt", "vu", "ip", "nw", "addrs", "hw"}
+	}
+	sql, err := t.UpsertSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+// CopyFromSQL returns a COPY ... FROM STDIN statement for the columns in
+// PointTable named by colnames, for loading rows written by CopyFrom or
+// PointCopyWriter.
+//
+// If no column names are provided, all columns will be copied.
+func (t *PointTableType) CopyFromSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	return "COPY " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") FROM STDIN (FORMAT binary)", nil
+}
+
+// type PointCopyWriter writes values of type Point to a stream in the
+// PostgreSQL binary COPY format.
+//
+// Call PointCopyWriter.Close after all values have been written.
+type PointCopyWriter struct {
+	fe   PointFieldEncoders
+	oids []pgx.Oid
+	cw   *pgtypes.CopyWriter
+}
+
+// NewCopyWriter creates a PointCopyWriter which writes the columns named by
+// colnames to w, in the order of colnames.
+//
+// If no column names are provided, all columns will be written.
+func (t *PointTableType) NewCopyWriter(w io.Writer, colnames ...string) (*PointCopyWriter, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	oids := make([]pgx.Oid, len(fe))
+	for i, index := range fe {
+		oids[i] = t.Oids[index]
+	}
+	return &PointCopyWriter{fe: fe, oids: oids, cw: pgtypes.NewCopyWriter(w)}, nil
+}
+
+// Write writes a single row to the COPY stream, with fields encoded from v.
+func (cw *PointCopyWriter) Write(v *Point) error {
+	args, err := cw.fe.Bind(v)
+	if err != nil {
+		return err
+	}
+	return cw.cw.WriteRow(cw.oids, args)
+}
+
+// Close writes the trailer of the COPY stream. The underlying io.Writer is not
+// closed.
+func (cw *PointCopyWriter) Close() error {
+	return cw.cw.Close()
+}
+
+// CopyFrom writes rows to w in the PostgreSQL binary COPY format, including
+// the header and trailer, for loading with the statement returned by
+// CopyFromSQL.
+//
+// If no column names are provided, all columns will be written.
+func (t *PointTableType) CopyFrom(w io.Writer, rows []Point, colnames ...string) error {
+	cw, err := t.NewCopyWriter(w, colnames...)
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		if err = cw.Write(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return cw.Close()
+}
+
 // type PointFieldScanners binds query/statement results to a value of type
 // Point.
 //
@@ -356,3 +1213,81 @@ func (fs PointFieldScanners) Bind(v *Point) ([]pgx.Scanner, error) {
 	}
 	return bound, nil
 }
+
+// CopyToSQL returns a COPY ... TO STDOUT statement for the columns in
+// PointTable named by colnames, for producing a stream which may be read by
+// CopyTo or CopyDecode.
+//
+// If no column names are provided, all columns will be copied.
+func (t *PointTableType) CopyToSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	return "COPY " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") TO STDOUT (FORMAT binary)", nil
+}
+
+// CopyDecode reads rows from r in the PostgreSQL binary COPY format, as
+// produced by the statement returned by CopyToSQL, calling fn with a new value
+// of type Point decoded from each row.
+//
+// The columns named by colnames must match the columns of the COPY statement,
+// in order. If no column names are provided, all columns will be decoded.
+func (t *PointTableType) CopyDecode(r io.Reader, fn func(*Point) error, colnames ...string) error {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fs, err := t.Scanners(colnames...)
+	if err != nil {
+		return err
+	}
+	cr, err := pgtypes.NewCopyReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		count, err := cr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if count != len(fs) {
+			return errors.New("unexpected field count " + strconv.Itoa(count) + " in COPY tuple for table " + t.TableName)
+		}
+		v := new(Point)
+		for _, index := range fs {
+			vr, err := cr.ReadValue(t.Oids[index])
+			if err != nil {
+				return err
+			}
+			s, ok := pgtypes.ValueScannerOf(t.UnboundScanners[index](v))
+			if !ok {
+				return errors.New("column " + t.Names[index] + " does not support decoding from COPY streams")
+			}
+			if err = s.ScanValue(vr); err != nil {
+				return err
+			}
+		}
+		if err = fn(v); err != nil {
+			return err
+		}
+	}
+}
+
+// CopyTo reads rows from r in the PostgreSQL binary COPY format, as produced
+// by the statement returned by CopyToSQL, returning a slice of values of type
+// Point decoded from the rows.
+//
+// If no column names are provided, all columns will be decoded.
+func (t *PointTableType) CopyTo(r io.Reader, colnames ...string) ([]Point, error) {
+	var rows []Point
+	err := t.CopyDecode(r, func(v *Point) error {
+		rows = append(rows, *v)
+		return nil
+	}, colnames...)
+	return rows, err
+}
@@ -0,0 +1,101 @@
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wdamron/pgx-gen/pgtypes"
+)
+
+func TestInsertSQL(t *testing.T) {
+	sql, err := PointTable.InsertSQL("id", "x", "addrs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "points" ("id", "x", "addrs") VALUES ($1, $2, $3)`; sql != want {
+		t.Errorf("InsertSQL = %s; want %s", sql, want)
+	}
+
+	// all columns are inserted by default
+	sql, err = PointTable.InsertSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(sql, `INSERT INTO "points" ("x", "y", "z", `) || !strings.HasSuffix(sql, ", $30)") {
+		t.Errorf("InsertSQL() = %s", sql)
+	}
+
+	if _, err := PointTable.InsertSQL("id", "nope"); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestInsertArgs(t *testing.T) {
+	v := &Point{u: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", X: []string{"a"}}
+	st, err := v.InsertArgs("id", "x", "j")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "points" ("id", "x", "j") VALUES ($1, $2, $3)`; st.SQL != want {
+		t.Errorf("SQL = %s; want %s", st.SQL, want)
+	}
+	if len(st.Oids) != 3 || st.Oids[0] != pgtypes.UUIDOid || st.Oids[1] != pgtypes.VarcharArrayOid || st.Oids[2] != pgtypes.JSONOid {
+		t.Errorf("Oids = %v", st.Oids)
+	}
+	// json is encoded in text format
+	if len(st.Formats) != 3 || st.Formats[0] != 1 || st.Formats[1] != 1 || st.Formats[2] != 0 {
+		t.Errorf("Formats = %v", st.Formats)
+	}
+	if len(st.Args) != 3 || len(st.Params()) != 3 {
+		t.Errorf("Args = %v", st.Args)
+	}
+}
+
+func TestBatchInsert(t *testing.T) {
+	rows := make([]Point, 3)
+	stmts, err := PointTable.BatchInsert(rows, "id", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 1 {
+		t.Fatalf("BatchInsert returned %d statements; want 1", len(stmts))
+	}
+	if want := `INSERT INTO "points" ("id", "x") VALUES ($1, $2), ($3, $4), ($5, $6)`; stmts[0].SQL != want {
+		t.Errorf("SQL = %s; want %s", stmts[0].SQL, want)
+	}
+	if len(stmts[0].Args) != 6 || len(stmts[0].Oids) != 6 || stmts[0].Oids[2] != pgtypes.UUIDOid {
+		t.Errorf("Oids = %v", stmts[0].Oids)
+	}
+
+	stmts, err = PointTable.BatchInsertReturning(rows[:1], []string{"id"}, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "points" ("x") VALUES ($1) RETURNING "id"::uuid AS __05`; len(stmts) != 1 || stmts[0].SQL != want {
+		t.Errorf("BatchInsertReturning = %v; want %s", stmts, want)
+	}
+
+	if stmts, err = PointTable.BatchInsert(nil); err != nil || len(stmts) != 0 {
+		t.Errorf("BatchInsert(nil) = %v, %v", stmts, err)
+	}
+}
+
+func TestBatchInsertChunks(t *testing.T) {
+	// all 30 columns are inserted, so each statement holds at most 2184 rows
+	rows := make([]Point, pgtypes.MaxParams/len(PointTable.Names)+1)
+	stmts, err := PointTable.BatchInsert(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stmts) != 2 {
+		t.Fatalf("BatchInsert returned %d statements; want 2", len(stmts))
+	}
+	for i, st := range stmts {
+		if len(st.Args) > pgtypes.MaxParams || len(st.Oids) != len(st.Args) || len(st.Formats) != len(st.Args) {
+			t.Errorf("statement %d has %d args, %d oids and %d formats", i, len(st.Args), len(st.Oids), len(st.Formats))
+		}
+	}
+	if n := len(stmts[1].Args); n != len(PointTable.Names) {
+		t.Errorf("the last statement has %d args; want %d", n, len(PointTable.Names))
+	}
+}
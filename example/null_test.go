@@ -0,0 +1,92 @@
+package example
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wdamron/pgx-gen/pgtypes"
+)
+
+func TestNilPointerEncode(t *testing.T) {
+	// nil pointer fields are written as NULL, with a field length of -1
+	for _, colname := range []string{"y", "z", "h", "id2", "j", "p", "bp", "nw", "vu"} {
+		var b bytes.Buffer
+		if err := PointTable.CopyFrom(&b, []Point{{}}, colname); err != nil {
+			t.Fatalf("%s: %v", colname, err)
+		}
+		if tuple := []byte{0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}; !bytes.HasSuffix(b.Bytes(), tuple) {
+			t.Errorf("%s: expected a NULL field, got %q", colname, b.Bytes())
+		}
+	}
+
+	v := &Point{}
+	st, err := v.InsertArgs("y", "id2", "n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, arg := range st.Args {
+		if arg == nil {
+			t.Errorf("Args[%d] is nil", i)
+		}
+	}
+}
+
+// decode the single row of a COPY stream of the columns named by colnames
+// into v, using the scanners bound to v
+func copyDecodeInto(t *testing.T, r *bytes.Buffer, v *Point, colnames ...string) {
+	t.Helper()
+	fs, err := PointTable.Scanners(colnames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanners, err := fs.Bind(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cr, err := pgtypes.NewCopyReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = cr.Next(); err != nil {
+		t.Fatal(err)
+	}
+	for i, index := range fs {
+		vr, err := cr.ReadValue(PointTable.Oids[index])
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, ok := pgtypes.ValueScannerOf(scanners[i])
+		if !ok {
+			t.Fatalf("no value scanner for column %s", colnames[i])
+		}
+		if err = s.ScanValue(vr); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestNullScan(t *testing.T) {
+	colnames := []string{"y", "j", "n"}
+	y, j := int64(7), "{}"
+	var b bytes.Buffer
+	if err := PointTable.CopyFrom(&b, []Point{{Y: &y, j: &j}}, colnames...); err != nil {
+		t.Fatal(err)
+	}
+	// non-NULL values allocate pointer fields
+	v := &Point{}
+	copyDecodeInto(t, &b, v, colnames...)
+	if v.Y == nil || *v.Y != y || v.j == nil || *v.j != j || v.n.Valid {
+		t.Errorf("decoded y=%v j=%v n=%v", v.Y, v.j, v.n)
+	}
+
+	// NULL values clear pointer fields and invalidate null wrappers
+	b.Reset()
+	if err := PointTable.CopyFrom(&b, []Point{{}}, colnames...); err != nil {
+		t.Fatal(err)
+	}
+	v.n.String, v.n.Valid = "x", true
+	copyDecodeInto(t, &b, v, colnames...)
+	if v.Y != nil || v.j != nil || v.n.Valid {
+		t.Errorf("decoded y=%v j=%v n=%v", v.Y, v.j, v.n)
+	}
+}
@@ -0,0 +1,51 @@
+package example
+
+import (
+	"testing"
+)
+
+func TestPKStatements(t *testing.T) {
+	if want := `WHERE "id" = $3`; PointTable.WherePK(2) != want {
+		t.Errorf("WherePK = %s; want %s", PointTable.WherePK(2), want)
+	}
+
+	sql, err := PointTable.UpdateByPKSQL("x", "y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `UPDATE "points" SET "x" = $1, "y" = $2 WHERE "id" = $3`; sql != want {
+		t.Errorf("UpdateByPKSQL = %s; want %s", sql, want)
+	}
+
+	if want := `DELETE FROM "points" WHERE "id" = $1`; PointTable.DeleteByPKSQL() != want {
+		t.Errorf("DeleteByPKSQL = %s; want %s", PointTable.DeleteByPKSQL(), want)
+	}
+
+	sql, err = PointTable.SelectByPKSQL("id", "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `SELECT "id"::uuid AS __05, "x"::varchar[] AS __00 FROM "points" WHERE "id" = $1`; sql != want {
+		t.Errorf("SelectByPKSQL = %s; want %s", sql, want)
+	}
+}
+
+func TestUpdateByPK(t *testing.T) {
+	v := &Point{u: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}
+	st, err := PointTable.UpdateByPK(v, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the primary key parameter follows the updated columns
+	if len(st.Args) != 2 || len(st.Oids) != 2 || st.Oids[1] != PointTable.Oids[PointTable.Index("id")] {
+		t.Errorf("Oids = %v", st.Oids)
+	}
+
+	st, err = PointTable.DeleteByPK(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Args) != 1 {
+		t.Errorf("Args = %v", st.Args)
+	}
+}
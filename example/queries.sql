@@ -0,0 +1,16 @@
+-- name: GetPoint :one
+-- param: id uuid
+-- result: Point
+SELECT * FROM points WHERE id = $1;
+
+-- name: ListPointIDs :many
+-- param: since timestampTz time.Time
+-- param: lim int4 *int
+-- column: id uuid
+-- column: y int4 *int64
+-- column: created_at timestamp with time zone
+SELECT id, y, created_at FROM points WHERE created_at > $1 LIMIT $2;
+
+-- name: DeletePoint :exec
+-- param: id uuid
+DELETE FROM points WHERE id = $1;
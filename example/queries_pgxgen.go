@@ -0,0 +1,126 @@
+package example
+
+// Generated by pgxgen (see queries.sql)
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/wdamron/pgx"
+	"github.com/wdamron/pgx-gen/pgtypes"
+)
+
+// GetPointSQL is the SQL text of the GetPoint query
+const GetPointSQL = `SELECT * FROM points WHERE id = $1`
+
+// GetPoint executes the GetPoint query with q, decoding the first row of the
+// results.
+//
+// If no rows are returned, pgx.ErrNoRows is returned.
+func GetPoint(q pgtypes.Querier, id string) (*Point, error) {
+	rows, err := q.Query(GetPointSQL, pgtypes.UUIDEncoderString(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, pgx.ErrNoRows
+	}
+	v := new(Point)
+	if err = v.DecodeRow(rows); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return v, rows.Err()
+}
+
+// ListPointIDsSQL is the SQL text of the ListPointIDs query
+const ListPointIDsSQL = `SELECT id, y, created_at FROM points WHERE created_at > $1 LIMIT $2`
+
+// ListPointIDsRow holds a row of the results of the ListPointIDs query.
+type ListPointIDsRow struct {
+	ID        string    `pgx:"name:id;type:uuid"`
+	Y         *int64    `pgx:"name:y;type:int4"`
+	CreatedAt time.Time `pgx:"name:created_at;type:timestamp with time zone"`
+}
+
+// listPointIDsRowScanners contains unbound scanners for the columns of
+// ListPointIDsRow, in the order of the ListPointIDs query results.
+var listPointIDsRowScanners = [3]func(*ListPointIDsRow) pgx.Scanner{
+	// Decode column id::uuid into v.ID
+	func(v *ListPointIDsRow) pgx.Scanner {
+		return pgtypes.UUIDScannerString(&v.ID)
+	},
+	// Decode column y::int4 into v.Y
+	func(v *ListPointIDsRow) pgx.Scanner {
+		return pgtypes.NullableScanner(func() { v.Y = nil }, func() pgx.Scanner {
+			v.Y = new(int64)
+			return pgtypes.IntoInt64(v.Y)
+		})
+	},
+	// Decode column created_at::timestampTz into v.CreatedAt
+	func(v *ListPointIDsRow) pgx.Scanner {
+		return pgtypes.TimestampTzScanner(&v.CreatedAt)
+	},
+}
+
+// DecodeRow decodes a single row/result from r into v. Columns are decoded
+// positionally, in the order of the ListPointIDs query results.
+//
+// If an error is returned, the caller should call Rows.Close()
+func (v *ListPointIDsRow) DecodeRow(r *pgx.Rows) error {
+	for i := range r.FieldDescriptions() {
+		vr, ok := r.NextColumn()
+		if !ok {
+			if vr != nil && vr.Err() != nil {
+				return vr.Err()
+			}
+			break
+		}
+		if i >= len(listPointIDsRowScanners) {
+			return errors.New("unexpected column " + vr.Type().Name + " in results of ListPointIDs")
+		}
+		if err := listPointIDsRowScanners[i](v).Scan(vr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListPointIDs executes the ListPointIDs query with q, decoding each row of
+// the results.
+func ListPointIDs(q pgtypes.Querier, since time.Time, lim *int) ([]ListPointIDsRow, error) {
+	if lim != nil && (int64(*lim) < math.MinInt32 || int64(*lim) > math.MaxInt32) {
+		return nil, errors.New("parameter lim of query ListPointIDs is out of range for int4")
+	}
+	var limEnc pgx.Encoder = pgtypes.NullEncoder(pgtypes.Int4Oid, 1)
+	if lim != nil {
+		limEnc = pgtypes.Int4Encoder(int32(*lim))
+	}
+	rows, err := q.Query(ListPointIDsSQL, pgtypes.TimestampTzEncoder(since), limEnc)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []ListPointIDsRow
+	for rows.Next() {
+		var v ListPointIDsRow
+		if err = v.DecodeRow(rows); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+// DeletePointSQL is the SQL text of the DeletePoint query
+const DeletePointSQL = `DELETE FROM points WHERE id = $1`
+
+// DeletePoint executes the DeletePoint query with e.
+func DeletePoint(e pgtypes.Execer, id string) (pgx.CommandTag, error) {
+	return e.Exec(DeletePointSQL, pgtypes.UUIDEncoderString(id))
+}
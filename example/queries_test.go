@@ -0,0 +1,24 @@
+package example
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQueryParamOverflow(t *testing.T) {
+	if strconv.IntSize == 32 {
+		t.Skip("int parameters cannot overflow int4")
+	}
+	lim := math.MaxInt32
+	lim++
+	// out of range parameters are rejected before the query is executed
+	rows, err := ListPointIDs(nil, time.Now(), &lim)
+	if err == nil || rows != nil {
+		t.Fatalf("ListPointIDs = %v, %v; expected an error", rows, err)
+	}
+	if want := "parameter lim of query ListPointIDs is out of range for int4"; err.Error() != want {
+		t.Errorf("error = %q; want %q", err, want)
+	}
+}
@@ -0,0 +1,41 @@
+package example
+
+import (
+	"testing"
+)
+
+func TestReturningSQL(t *testing.T) {
+	sql, err := PointTable.ReturningSQL("id", "ps", "addrs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// columns are cast before they are aliased
+	if want := `RETURNING "id"::uuid AS __05, "ps"::numeric[] AS __0f, "addrs"::inet[] AS __1c`; sql != want {
+		t.Errorf("ReturningSQL = %s; want %s", sql, want)
+	}
+	if _, err := PointTable.ReturningSQL("nope"); err == nil {
+		t.Error("expected an error for an unknown column")
+	}
+}
+
+func TestInsertReturning(t *testing.T) {
+	v := &Point{u: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}
+	st, err := v.InsertReturning([]string{"y"}, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "points" ("id") VALUES ($1) RETURNING "y"::int4 AS __01`; st.SQL != want {
+		t.Errorf("InsertReturning = %s; want %s", st.SQL, want)
+	}
+	if len(st.Args) != 1 {
+		t.Errorf("Args = %v", st.Args)
+	}
+
+	st, err = PointTable.UpdateByPKReturning(v, []string{"y"}, "x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `UPDATE "points" SET "x" = $1 WHERE "id" = $2 RETURNING "y"::int4 AS __01`; st.SQL != want {
+		t.Errorf("UpdateByPKReturning = %s; want %s", st.SQL, want)
+	}
+}
@@ -0,0 +1,26 @@
+package example
+
+import (
+	"testing"
+)
+
+func TestUpsertSQL(t *testing.T) {
+	sql, err := PointTable.UpsertSQL("id", "x", "id2", "h2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `INSERT INTO "points" ("id", "x", "id2", "h2") VALUES ($1, $2, $3, $4)` +
+		` ON CONFLICT ("id") DO UPDATE SET "x" = EXCLUDED."x", "h2" = COALESCE(EXCLUDED."h2", "points"."h2")`
+	if sql != want {
+		t.Errorf("UpsertSQL = %s; want %s", sql, want)
+	}
+
+	// conflicting rows are left as they are if no columns are updated
+	sql, err = PointTable.UpsertSQL("id", "id2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `INSERT INTO "points" ("id", "id2") VALUES ($1, $2) ON CONFLICT ("id") DO NOTHING`; sql != want {
+		t.Errorf("UpsertSQL = %s; want %s", sql, want)
+	}
+}
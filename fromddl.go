@@ -0,0 +1,263 @@
+package pgxgen
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PreferredFieldTypes contains the preferred Go field type for each column data
+// type, for structs generated from a schema (see GenStructs). Nullable columns
+// prefer a pointer to the same type, unless the preferred type is a pointer.
+var PreferredFieldTypes = map[string]string{
+	"bool":          "bool",
+	"int2":          "int16",
+	"int4":          "int32",
+	"int8":          "int64",
+	"real":          "float32",
+	"float":         "float64",
+	"bytea":         "[]byte",
+	"text":          "string",
+	"varchar":       "string",
+	"date":          "time.Time",
+	"timestamp":     "time.Time",
+	"timestampTz":   "time.Time",
+	"time":          "time.Time",
+	"timetz":        "time.Time",
+	"bool[]":        "[]bool",
+	"int2[]":        "[]int16",
+	"int4[]":        "[]int32",
+	"int8[]":        "[]int64",
+	"real[]":        "[]float32",
+	"float[]":       "[]float64",
+	"text[]":        "[]string",
+	"varchar[]":     "[]string",
+	"timestamp[]":   "[]time.Time",
+	"timestampTz[]": "[]time.Time",
+	"date[]":        "[]time.Time",
+	"time[]":        "[]time.Time",
+	"timetz[]":      "[]time.Time",
+	"bytea[]":       "[][]byte",
+	"uuid[]":        "[]string",
+	"numeric[]":     "[]*big.Rat",
+	"interval":      "pgtypes.Interval",
+	"interval[]":    "[]pgtypes.Interval",
+	"inet":          "net.IP",
+	"inet[]":        "[]net.IP",
+	"cidr":          "*net.IPNet",
+	"cidr[]":        "[]*net.IPNet",
+	"macaddr":       "net.HardwareAddr",
+	"macaddr[]":     "[]net.HardwareAddr",
+	"macaddr8":      "net.HardwareAddr",
+	"macaddr8[]":    "[]net.HardwareAddr",
+	"hstore":        "map[string]string",
+	"uuid":          "string",
+	"numeric":       "*big.Rat",
+	"json":          "json.RawMessage",
+	"jsonb":         "json.RawMessage",
+	"json[]":        "[]json.RawMessage",
+	"jsonb[]":       "[]json.RawMessage",
+}
+
+// FieldType returns a Go field type for columns of type coltype which has
+// entries in both the Encoders and Decoders op maps, preferring a pointer type
+// if nullable is true. If no such type exists, or if the generator has no
+// matching data type for coltype, the returned string will be empty.
+//
+// Columns of the json types may hold any field type, so the preferred type is
+// returned for them.
+func FieldType(coltype string, nullable bool) string {
+	if JSONDataTypes[coltype] {
+		if nullable {
+			return "*" + PreferredFieldTypes[coltype]
+		}
+		return PreferredFieldTypes[coltype]
+	}
+	if DataTypeNames[coltype] == "" || Decoders[coltype] == nil {
+		return ""
+	}
+	var candidates []string
+	if pref := PreferredFieldTypes[coltype]; strings.HasPrefix(pref, "*") {
+		candidates = append(candidates, pref)
+	} else if pref != "" {
+		if nullable {
+			candidates = append(candidates, "*"+pref, pref)
+		} else {
+			candidates = append(candidates, pref, "*"+pref)
+		}
+	}
+	others := make([]string, 0, len(Decoders[coltype]))
+	for ftype := range Decoders[coltype] {
+		others = append(others, ftype)
+	}
+	sort.Slice(others, func(i, j int) bool {
+		pi, pj := strings.HasPrefix(others[i], "*"), strings.HasPrefix(others[j], "*")
+		if pi != pj {
+			return pi == nullable
+		}
+		return others[i] < others[j]
+	})
+	for _, ftype := range append(candidates, others...) {
+		if _, ok := Encoders[ftype][coltype]; !ok {
+			continue
+		}
+		if _, ok := Decoders[coltype][ftype]; ok {
+			return ftype
+		}
+	}
+	return ""
+}
+
+// GenStructs generates Go struct definitions with pgx tags for each table
+// within schema, returning formatted source for package pkg along with a
+// warning for each column or constraint which could not be represented.
+func GenStructs(pkg string, schema *Schema) ([]byte, []string, error) {
+	var warnings []string
+	imports := map[string]bool{}
+	var body string
+	for _, t := range schema.Tables {
+		structName := GoName(strings.TrimPrefix(t.Name, "public."))
+		if structName == "" {
+			warnings = append(warnings, fmt.Sprintf("line %d: table %s: no valid Go name", t.Line, t.Name))
+			continue
+		}
+		uniqueOpts := map[string]string{}
+		for _, u := range t.Unique {
+			opt := ColumnUniqueKey
+			if len(u.Columns) > 1 {
+				name := u.Name
+				if name == "" {
+					name = strings.TrimPrefix(t.Name, "public.") + "_" + strings.Join(u.Columns, "_") + "_key"
+				}
+				opt += ":" + name
+			}
+			for _, colname := range u.Columns {
+				if uniqueOpts[colname] != "" {
+					warnings = append(warnings, fmt.Sprintf("line %d: table %s: column %s is part of more than one unique constraint", t.Line, t.Name, colname))
+					continue
+				}
+				uniqueOpts[colname] = opt
+			}
+		}
+		pk := map[string]bool{}
+		for _, colname := range t.PrimaryKey {
+			pk[colname] = true
+		}
+
+		body += fmt.Sprintf("// %s holds a row of the %s table\n", structName, t.Name)
+		body += fmt.Sprintf("type %s struct {\n", structName)
+		body += fmt.Sprintf("_ struct{} `pgx:%s`\n", strconv.Quote(TableNameKey+":"+strings.TrimPrefix(t.Name, "public.")))
+		fieldNames := map[string]bool{}
+		for i, c := range t.Columns {
+			coltype := NormalizeSchemaType(c.Type)
+			if st := schema.Type(coltype); st != nil {
+				warnings = append(warnings, fmt.Sprintf("line %d: %s.%s: %s type %s is not supported", c.Line, t.Name, c.Name, st.Kind, st.Name))
+				body += fmt.Sprintf("// %s %s: unsupported %s type\n", c.Name, c.Type, st.Kind)
+				continue
+			}
+			ftype := FieldType(coltype, !c.NotNull)
+			if ftype == "" {
+				warnings = append(warnings, fmt.Sprintf("line %d: %s.%s: data type %s is not supported", c.Line, t.Name, c.Name, c.Type))
+				body += fmt.Sprintf("// %s %s: unsupported data type\n", c.Name, c.Type)
+				continue
+			}
+			fieldName := GoName(c.Name)
+			if fieldName == "" {
+				fieldName = "Column" + strconv.Itoa(i+1)
+			}
+			for fieldNames[fieldName] {
+				fieldName += "_"
+			}
+			fieldNames[fieldName] = true
+			if strings.Contains(ftype, "time.") {
+				imports["time"] = true
+			}
+			if strings.Contains(ftype, "json.") {
+				imports["encoding/json"] = true
+			}
+			if strings.Contains(ftype, "big.") {
+				imports["math/big"] = true
+			}
+			if strings.Contains(ftype, "net.") {
+				imports["net"] = true
+			}
+			if strings.Contains(ftype, "netip.") {
+				imports["net/netip"] = true
+			}
+			if strings.Contains(ftype, "pgtypes.") {
+				imports[PGTYPES_PKG] = true
+			}
+
+			tagType := coltype
+			if precision, scale, ok := NumericTypmod(strings.TrimSuffix(c.Type, "[]")); ok {
+				tagType = fmt.Sprintf("numeric(%d,%d)%s", precision, scale, strings.TrimPrefix(coltype, "numeric"))
+			}
+			opts := []string{ColumnNameKey + ":" + c.Name, ColumnTypeKey + ":" + tagType}
+			if pk[c.Name] {
+				opts = append(opts, ColumnPKKey)
+			} else if nullable := strings.HasPrefix(ftype, "*") || strings.HasPrefix(ftype, "pgx.Null"); nullable == c.NotNull {
+				if c.NotNull {
+					opts = append(opts, ColumnNullKey+":false")
+				} else {
+					opts = append(opts, ColumnNullKey)
+				}
+			}
+			if opt := uniqueOpts[c.Name]; opt != "" {
+				opts = append(opts, opt)
+			}
+			if c.Default != "" {
+				if strings.ContainsAny(c.Default, ";`") {
+					warnings = append(warnings, fmt.Sprintf("line %d: %s.%s: default value cannot be represented in a tag: %s", c.Line, t.Name, c.Name, c.Default))
+				} else {
+					opts = append(opts, ColumnDefaultKey+":"+c.Default)
+				}
+			}
+			body += fmt.Sprintf("%s %s `%s:%s`\n", fieldName, ftype, ColumnTagName, strconv.Quote(strings.Join(opts, ";")))
+		}
+		body += "}\n\n"
+	}
+
+	out := fmt.Sprintf("package %s\n\n", pkg)
+	if len(imports) != 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, strconv.Quote(path))
+		}
+		sort.Strings(paths)
+		out += "import (\n" + strings.Join(paths, "\n") + "\n)\n\n"
+	}
+	src, err := format.Source([]byte(out + body))
+	if err != nil {
+		return nil, warnings, err
+	}
+	return src, warnings, nil
+}
+
+// common initialisms which are upper-cased within Go names (see GoName)
+var goInitialisms = map[string]bool{
+	"id": true, "ip": true, "json": true, "sql": true, "uid": true, "uri": true,
+	"url": true, "uuid": true, "http": true, "html": true, "api": true,
+}
+
+// GoName converts a SQL name to an exported Go name (e.g. user_id -> UserID).
+// If the name contains no letters or digits, the returned string will be empty.
+func GoName(sqlName string) string {
+	out := ""
+	for _, word := range strings.FieldsFunc(sqlName, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if goInitialisms[strings.ToLower(word)] {
+			out += strings.ToUpper(word)
+			continue
+		}
+		runes := []rune(word)
+		out += string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	if out != "" && !unicode.IsLetter([]rune(out)[0]) {
+		out = "X" + out
+	}
+	return out
+}
@@ -0,0 +1,101 @@
+package pgxgen
+
+import (
+	"strings"
+	"testing"
+)
+
+// a table as written by pg_dump --schema-only
+const fromDDLDump = `
+CREATE TABLE public.events (
+    id integer NOT NULL,
+    payload jsonb NOT NULL,
+    meta json,
+    tags jsonb[],
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    seen timestamptz
+);
+
+CREATE SEQUENCE public.events_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.events_id_seq OWNED BY public.events.id;
+
+ALTER TABLE ONLY public.events ALTER COLUMN id SET DEFAULT nextval('public.events_id_seq'::regclass);
+
+ALTER TABLE ONLY public.events
+    ADD CONSTRAINT events_pkey PRIMARY KEY (id);
+`
+
+func TestGenStructsDump(t *testing.T) {
+	schema, err := ParseSchema(fromDDLDump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, warnings, err := GenStructs("p", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range warnings {
+		t.Error(w)
+	}
+	// fields are aligned by gofmt:
+	fields := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"\"encoding/json\"",
+		"\"time\"",
+		"ID int32 `pgx:\"name:id;type:int4;pk;default:nextval('public.events_id_seq'::regclass)\"`",
+		"Payload json.RawMessage `pgx:\"name:payload;type:jsonb\"`",
+		"Meta *json.RawMessage `pgx:\"name:meta;type:json\"`",
+		"Tags *[]json.RawMessage `pgx:\"name:tags;type:jsonb[]\"`",
+		"CreatedAt time.Time `pgx:\"name:created_at;type:timestampTz;default:now()\"`",
+		"Seen *time.Time `pgx:\"name:seen;type:timestampTz\"`",
+	} {
+		if !strings.Contains(fields, want) {
+			t.Errorf("missing %s in\n%s", want, src)
+		}
+	}
+
+	// the generated structs match the schema:
+	f := parseTestFile(t, "events.go", string(src))
+	if _, err := f.Gen(); err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err := f.CheckSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mismatches {
+		t.Error(m)
+	}
+}
+
+func TestGenStructsSerial(t *testing.T) {
+	schema, err := ParseSchema(`CREATE TABLE items (id bigserial PRIMARY KEY, n smallserial, qty serial4 UNIQUE);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, warnings, err := GenStructs("p", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, w := range warnings {
+		t.Error(w)
+	}
+	// fields are aligned by gofmt:
+	fields := strings.Join(strings.Fields(string(src)), " ")
+	for _, want := range []string{
+		"ID int64 `pgx:\"name:id;type:int8;pk;default:nextval('items_id_seq'::regclass)\"`",
+		"N int16 `pgx:\"name:n;type:int2;default:nextval('items_n_seq'::regclass)\"`",
+		"Qty int32 `pgx:\"name:qty;type:int4;unique;default:nextval('items_qty_seq'::regclass)\"`",
+	} {
+		if !strings.Contains(fields, want) {
+			t.Errorf("missing %s in\n%s", want, src)
+		}
+	}
+}
@@ -70,12 +70,20 @@ func (f *File) gen() ([]byte, error) {
 		// ensure std packages are imported when columns are present:
 		stdImports["errors"] = ""
 		stdImports["encoding/hex"] = ""
+		stdImports["io"] = ""
+		stdImports["strconv"] = ""
+		stdImports["strings"] = ""
 		// ensure driver is imported when columns are present:
 		otherImports[f.Driver] = ""
 		// ensure pgtypes is imported when columns are present:
 		otherImports[PGTYPES_PKG] = ""
 
 		for _, c := range cols {
+			// pointer and null wrapper fields are assigned by their scanners, so
+			// the package of the field type must be imported:
+			if c.StructField.Type[0] == '*' || c.NullValue != "" {
+				addFieldTypeImports(c.StructField.Type, stdImports, otherImports)
+			}
 			switch c.Type {
 			case "uuid":
 				ftype := c.StructField.Type
@@ -94,6 +102,10 @@ func (f *File) gen() ([]byte, error) {
 			}
 		}
 
+		if err := checkPKColumns(&s); err != nil {
+			return nil, err
+		}
+
 		// generate type def for {struct-name}TableType struct:
 		body += genTableType(&s)
 
@@ -128,6 +140,60 @@ func (f *File) gen() ([]byte, error) {
 		// generate method def for {struct-name}FieldEncoders.Bind:
 		body += genEncodersBind(&s)
 
+		// generate method def for {struct-name}FieldEncoders.Statement:
+		body += genEncodersStatement(&s)
+
+		// generate method def for ({struct-name})TableType.CreateTableSQL:
+		body += genCreateTableSQLMethod(&s)
+
+		// generate method def for ({struct-name})TableType.InsertSQL:
+		body += genInsertSQLMethod(&s)
+
+		// generate method def for {struct-name}.InsertArgs:
+		body += genInsertArgsMethod(&s)
+
+		// generate method def for ({struct-name})TableType.ReturningSQL:
+		body += genReturningSQLMethod(&s)
+
+		// generate method def for {struct-name}.InsertReturning:
+		body += genInsertReturningMethod(&s)
+
+		// generate method def for {struct-name}.QueryReturning:
+		body += genQueryReturningMethod(&s)
+
+		// generate method defs for ({struct-name})TableType.BatchInsert and
+		// ({struct-name})TableType.BatchInsertReturning:
+		body += genBatchInsertMethods(&s)
+
+		if len(s.PKColumns()) != 0 {
+			// generate method def for ({struct-name})TableType.WherePK:
+			body += genWherePKMethod(&s)
+
+			// generate method defs for ({struct-name})TableType.UpdateByPK(SQL):
+			body += genUpdateByPKMethods(&s)
+
+			// generate method def for ({struct-name})TableType.UpdateByPKReturning:
+			body += genUpdateByPKReturningMethod(&s)
+
+			// generate method defs for ({struct-name})TableType.DeleteByPK(SQL):
+			body += genDeleteByPKMethods(&s)
+
+			// generate method defs for ({struct-name})TableType.SelectByPK(SQL):
+			body += genSelectByPKMethods(&s)
+		}
+
+		// generate method defs for ({struct-name})TableType.Upsert(SQL):
+		if target, _ := s.ConflictTarget(); len(target) != 0 {
+			body += genUpsertMethods(&s)
+		}
+
+		// generate method def for ({struct-name})TableType.CopyFromSQL:
+		body += genCopyFromSQLMethod(&s)
+
+		// generate type def for {struct-name}CopyWriter and its methods, along
+		// with method def for ({struct-name})TableType.CopyFrom:
+		body += genCopyWriter(&s)
+
 		// generate type def for {struct-name}FieldScanners:
 		body += genFieldScannersType(&s)
 
@@ -136,6 +202,13 @@ func (f *File) gen() ([]byte, error) {
 
 		// generate method def for {struct-name}FieldScanners.Bind:
 		body += genScannersBind(&s)
+
+		// generate method def for ({struct-name})TableType.CopyToSQL:
+		body += genCopyToSQLMethod(&s)
+
+		// generate method defs for ({struct-name})TableType.CopyDecode and
+		// ({struct-name})TableType.CopyTo:
+		body += genCopyDecodeMethods(&s)
 	}
 
 	out += genImports(f, stdImports, otherImports)
@@ -0,0 +1,197 @@
+package pgxgen
+
+import (
+	"fmt"
+)
+
+const copyFromSQLMethodFmt = `
+%s
+func (t *%sTableType) CopyFromSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	return "COPY " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") FROM STDIN (FORMAT binary)", nil
+}
+
+`
+
+// generate method def for ({struct-name})TableType.CopyFromSQL
+func genCopyFromSQLMethod(s *Struct) string {
+	doc := AutoCommentf("CopyFromSQL returns a COPY ... FROM STDIN statement for the columns in %sTable named by colnames, for loading rows written by CopyFrom or %sCopyWriter.\n", s.Name, s.Name)
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns will be copied.")
+	return fmt.Sprintf(copyFromSQLMethodFmt, doc, s.Name)
+}
+
+const copyWriterFmt = `
+%s
+type %sCopyWriter struct {
+	fe   %sFieldEncoders
+	oids []pgx.Oid
+	cw   *pgtypes.CopyWriter
+}
+
+%s
+func (t *%sTableType) NewCopyWriter(w io.Writer, colnames ...string) (*%sCopyWriter, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	oids := make([]pgx.Oid, len(fe))
+	for i, index := range fe {
+		oids[i] = t.Oids[index]
+	}
+	return &%sCopyWriter{fe: fe, oids: oids, cw: pgtypes.NewCopyWriter(w)}, nil
+}
+
+%s
+func (cw *%sCopyWriter) Write(v *%s) error {
+	args, err := cw.fe.Bind(v)
+	if err != nil {
+		return err
+	}
+	return cw.cw.WriteRow(cw.oids, args)
+}
+
+%s
+func (cw *%sCopyWriter) Close() error {
+	return cw.cw.Close()
+}
+
+%s
+func (t *%sTableType) CopyFrom(w io.Writer, rows []%s, colnames ...string) error {
+	cw, err := t.NewCopyWriter(w, colnames...)
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		if err = cw.Write(&rows[i]); err != nil {
+			return err
+		}
+	}
+	return cw.Close()
+}
+
+`
+
+// generate type def for {struct-name}CopyWriter, along with method defs for
+// ({struct-name})TableType.NewCopyWriter, {struct-name}CopyWriter.Write,
+// {struct-name}CopyWriter.Close and ({struct-name})TableType.CopyFrom
+func genCopyWriter(s *Struct) string {
+	typeDoc := AutoCommentf("type %sCopyWriter writes values of type %s to a stream in the PostgreSQL binary COPY format.\n", s.Name, s.Name)
+	typeDoc += "//\n"
+	typeDoc += AutoCommentf("Call %sCopyWriter.Close after all values have been written.", s.Name)
+	newDoc := AutoCommentf("NewCopyWriter creates a %sCopyWriter which writes the columns named by colnames to w, in the order of colnames.\n", s.Name)
+	newDoc += "//\n"
+	newDoc += AutoComment("If no column names are provided, all columns will be written.")
+	writeDoc := AutoComment("Write writes a single row to the COPY stream, with fields encoded from v.")
+	closeDoc := AutoComment("Close writes the trailer of the COPY stream. The underlying io.Writer is not closed.")
+	copyDoc := AutoCommentLn("CopyFrom writes rows to w in the PostgreSQL binary COPY format, including the header and trailer, for loading with the statement returned by CopyFromSQL.")
+	copyDoc += "//\n"
+	copyDoc += AutoComment("If no column names are provided, all columns will be written.")
+	return fmt.Sprintf(copyWriterFmt,
+		typeDoc, s.Name, s.Name,
+		newDoc, s.Name, s.Name, s.Name,
+		writeDoc, s.Name, s.Name,
+		closeDoc, s.Name,
+		copyDoc, s.Name, s.Name)
+}
+
+const copyToSQLMethodFmt = `
+%s
+func (t *%sTableType) CopyToSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	return "COPY " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") TO STDOUT (FORMAT binary)", nil
+}
+
+`
+
+// generate method def for ({struct-name})TableType.CopyToSQL
+func genCopyToSQLMethod(s *Struct) string {
+	doc := AutoCommentf("CopyToSQL returns a COPY ... TO STDOUT statement for the columns in %sTable named by colnames, for producing a stream which may be read by CopyTo or CopyDecode.\n", s.Name)
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns will be copied.")
+	return fmt.Sprintf(copyToSQLMethodFmt, doc, s.Name)
+}
+
+const copyDecodeMethodFmt = `
+%s
+func (t *%sTableType) CopyDecode(r io.Reader, fn func(*%s) error, colnames ...string) error {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fs, err := t.Scanners(colnames...)
+	if err != nil {
+		return err
+	}
+	cr, err := pgtypes.NewCopyReader(r)
+	if err != nil {
+		return err
+	}
+	for {
+		count, err := cr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if count != len(fs) {
+			return errors.New("unexpected field count " + strconv.Itoa(count) + " in COPY tuple for table " + t.TableName)
+		}
+		v := new(%s)
+		for _, index := range fs {
+			vr, err := cr.ReadValue(t.Oids[index])
+			if err != nil {
+				return err
+			}
+			s, ok := pgtypes.ValueScannerOf(t.UnboundScanners[index](v))
+			if !ok {
+				return errors.New("column " + t.Names[index] + " does not support decoding from COPY streams")
+			}
+			if err = s.ScanValue(vr); err != nil {
+				return err
+			}
+		}
+		if err = fn(v); err != nil {
+			return err
+		}
+	}
+}
+
+%s
+func (t *%sTableType) CopyTo(r io.Reader, colnames ...string) ([]%s, error) {
+	var rows []%s
+	err := t.CopyDecode(r, func(v *%s) error {
+		rows = append(rows, *v)
+		return nil
+	}, colnames...)
+	return rows, err
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.CopyDecode and
+// ({struct-name})TableType.CopyTo
+func genCopyDecodeMethods(s *Struct) string {
+	decodeDoc := AutoCommentf("CopyDecode reads rows from r in the PostgreSQL binary COPY format, as produced by the statement returned by CopyToSQL, calling fn with a new value of type %s decoded from each row.\n", s.Name)
+	decodeDoc += "//\n"
+	decodeDoc += AutoComment("The columns named by colnames must match the columns of the COPY statement, in order. If no column names are provided, all columns will be decoded.")
+	copyDoc := AutoCommentf("CopyTo reads rows from r in the PostgreSQL binary COPY format, as produced by the statement returned by CopyToSQL, returning a slice of values of type %s decoded from the rows.\n", s.Name)
+	copyDoc += "//\n"
+	copyDoc += AutoComment("If no column names are provided, all columns will be decoded.")
+	return fmt.Sprintf(copyDecodeMethodFmt,
+		decodeDoc, s.Name, s.Name, s.Name,
+		copyDoc, s.Name, s.Name, s.Name, s.Name)
+}
@@ -0,0 +1,19 @@
+package pgxgen
+
+import (
+	"fmt"
+)
+
+const createTableSQLMethodFmt = `
+%s
+func (t *%sTableType) CreateTableSQL() string {
+	return %s
+}
+
+`
+
+// generate method def for ({struct-name})TableType.CreateTableSQL
+func genCreateTableSQLMethod(s *Struct) string {
+	doc := AutoCommentf("CreateTableSQL returns a CREATE TABLE statement for %sTable.", s.Name)
+	return fmt.Sprintf(createTableSQLMethodFmt, doc, s.Name, genStringLit(s.CreateTableSQL()))
+}
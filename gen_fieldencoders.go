@@ -60,3 +60,33 @@ func genEncodersBind(s *Struct) string {
 	doc += AutoCommentf("Encoders are bound positionally, in correspondence with the field indexes stored within the %sFieldEncoders slice.", s.Name)
 	return fmt.Sprintf(encodersBindFmt, doc, s.Name, s.Name, s.Name, s.Name)
 }
+
+const encodersStatementFmt = `
+%s
+func (fe %sFieldEncoders) Statement(sql string, v *%s) (*pgtypes.Statement, error) {
+	args, err := fe.Bind(v)
+	if err != nil {
+		return nil, err
+	}
+	st := &pgtypes.Statement{
+		SQL:     sql,
+		Oids:    make([]pgx.Oid, len(fe)),
+		Formats: make([]int, len(fe)),
+		Args:    args,
+	}
+	for i, index := range fe {
+		st.Oids[i] = %sTable.Oids[index]
+		st.Formats[i] = %sTable.Formats[index]
+	}
+	return st, nil
+}
+
+`
+
+// generate method def for {struct-name}FieldEncoders.Statement
+func genEncodersStatement(s *Struct) string {
+	doc := AutoCommentLn("Statement binds query/statement parameter encoders for v, returning them along with sql and the oids and format codes of the parameters.")
+	doc += "//\n"
+	doc += AutoCommentf("Parameters are bound positionally, in correspondence with the field indexes stored within the %sFieldEncoders slice.", s.Name)
+	return fmt.Sprintf(encodersStatementFmt, doc, s.Name, s.Name, s.Name, s.Name)
+}
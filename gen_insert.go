@@ -0,0 +1,133 @@
+package pgxgen
+
+import (
+	"fmt"
+)
+
+const insertSQLMethodFmt = `
+%s
+func (t *%sTableType) InsertSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	params := make([]string, len(colnames))
+	for i := range colnames {
+		params[i] = "$" + strconv.Itoa(i+1)
+	}
+	return "INSERT INTO " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") VALUES (" + strings.Join(params, ", ") + ")", nil
+}
+
+`
+
+// generate method def for ({struct-name})TableType.InsertSQL
+func genInsertSQLMethod(s *Struct) string {
+	doc := AutoCommentf("InsertSQL returns an INSERT statement for the columns in %sTable named by colnames, with parameter placeholders in the same order as colnames.\n", s.Name)
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns will be inserted.")
+	return fmt.Sprintf(insertSQLMethodFmt, doc, s.Name)
+}
+
+const insertArgsMethodFmt = `
+%s
+func (v *%s) InsertArgs(colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = %sTable.Names[:]
+	}
+	sql, err := %sTable.InsertSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := %sTable.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+`
+
+// generate method def for {struct-name}.InsertArgs
+func genInsertArgsMethod(s *Struct) string {
+	doc := AutoCommentLn("InsertArgs returns an INSERT statement for the columns named by colnames, along with parameter oids, format codes and encoders bound to v.")
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns will be inserted.")
+	return fmt.Sprintf(insertArgsMethodFmt, doc, s.Name, s.Name, s.Name, s.Name)
+}
+
+const batchInsertMethodsFmt = `
+%s
+func (t *%sTableType) BatchInsert(rows []%s, colnames ...string) ([]*pgtypes.Statement, error) {
+	return t.batchInsert(rows, "", colnames)
+}
+
+%s
+func (t *%sTableType) BatchInsertReturning(rows []%s, returning []string, colnames ...string) ([]*pgtypes.Statement, error) {
+	ret, err := t.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	return t.batchInsert(rows, " "+ret, colnames)
+}
+
+func (t *%sTableType) batchInsert(rows []%s, suffix string, colnames []string) ([]*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = t.Names[:]
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	prefix := "INSERT INTO " + pgtypes.QuoteTableName(t.TableName) + " (" + strings.Join(pgtypes.QuoteIdentifiers(colnames), ", ") + ") VALUES "
+	chunkSize := pgtypes.MaxParams / len(fe)
+	stmts := make([]*pgtypes.Statement, 0, (len(rows)+chunkSize-1)/chunkSize)
+	params := make([]string, len(fe))
+	for start := 0; start < len(rows); start += chunkSize {
+		end := start + chunkSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		n := (end - start) * len(fe)
+		st := &pgtypes.Statement{
+			Oids:    make([]pgx.Oid, 0, n),
+			Formats: make([]int, 0, n),
+			Args:    make([]pgx.Encoder, 0, n),
+		}
+		values := make([]string, 0, end-start)
+		for i := start; i < end; i++ {
+			args, err := fe.Bind(&rows[i])
+			if err != nil {
+				return nil, err
+			}
+			for j, index := range fe {
+				params[j] = "$" + strconv.Itoa(len(st.Args)+1)
+				st.Oids = append(st.Oids, t.Oids[index])
+				st.Formats = append(st.Formats, t.Formats[index])
+				st.Args = append(st.Args, args[j])
+			}
+			values = append(values, "("+strings.Join(params, ", ")+")")
+		}
+		st.SQL = prefix + strings.Join(values, ", ") + suffix
+		stmts = append(stmts, st)
+	}
+	return stmts, nil
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.BatchInsert and
+// ({struct-name})TableType.BatchInsertReturning
+func genBatchInsertMethods(s *Struct) string {
+	batchDoc := AutoCommentf("BatchInsert returns multi-row INSERT statements for the columns in %sTable named by colnames, along with parameter oids, format codes and encoders bound to each of rows.\n", s.Name)
+	batchDoc += "//\n"
+	batchDoc += AutoComment("Rows are split across as many statements as needed to keep each statement within the bind parameter limit (see pgtypes.MaxParams). If no column names are provided, all columns will be inserted.")
+	returningDoc := AutoCommentLn("BatchInsertReturning is like BatchInsert, with a RETURNING clause for the columns named by returning appended to each statement.")
+	returningDoc += "//\n"
+	returningDoc += AutoCommentf("Returned columns are aliased (see Alias), so results may be decoded quickly with %s.DecodeRow. If no returned column names are provided, all columns will be returned.", s.Name)
+	return fmt.Sprintf(batchInsertMethodsFmt,
+		batchDoc, s.Name, s.Name,
+		returningDoc, s.Name, s.Name,
+		s.Name, s.Name)
+}
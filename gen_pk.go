@@ -0,0 +1,176 @@
+package pgxgen
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// checkPKColumns returns an error if any primary key column of s has a data
+// type which cannot be compared within a WHERE clause, or may hold a null
+// value (which never matches in a WHERE clause)
+func checkPKColumns(s *Struct) error {
+	for _, c := range s.PKColumns() {
+		if NonComparableDataTypes[c.Type] {
+			return fmt.Errorf("primary key column cannot be compared in a WHERE clause: %s.%s (coltype=%s)", s.Name, c.StructField.Name, c.Type)
+		}
+		if c.NullableField() || c.Spec[ColumnNullKey] == "1" {
+			return fmt.Errorf("primary key column cannot be null: %s.%s (type=%s)", s.Name, c.StructField.Name, c.StructField.Type)
+		}
+	}
+	return nil
+}
+
+// format names as a Go []string literal
+func genStringSlice(names []string) string {
+	out := "[]string{"
+	for i, name := range names {
+		if i != 0 {
+			out += ", "
+		}
+		out += strconv.Quote(name)
+	}
+	return out + "}"
+}
+
+// names of the columns of s which are not part of the primary key
+func nonPKColumnNames(s *Struct) []string {
+	names := []string{}
+	for _, c := range s.Columns {
+		if !c.IsPK() {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+const wherePKMethodFmt = `
+%s
+func (t *%sTableType) WherePK(offset int) string {
+	conds := make([]string, len(t.PrimaryKey))
+	for i, colname := range t.PrimaryKey {
+		conds[i] = pgtypes.QuoteIdentifier(colname) + " = $" + strconv.Itoa(offset+i+1)
+	}
+	return "WHERE " + strings.Join(conds, " AND ")
+}
+
+`
+
+// generate method def for ({struct-name})TableType.WherePK
+func genWherePKMethod(s *Struct) string {
+	doc := AutoCommentf("WherePK returns a WHERE clause matching the primary key columns of %sTable.\n", s.Name)
+	doc += "//\n"
+	doc += AutoComment("Parameter placeholders are numbered from offset+1, in the order of PrimaryKey.")
+	return fmt.Sprintf(wherePKMethodFmt, doc, s.Name)
+}
+
+const updateByPKMethodsFmt = `
+%s
+func (t *%sTableType) UpdateByPKSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = %s
+	}
+	if len(colnames) == 0 {
+		return "", errors.New("no columns to update in %sTable")
+	}
+	if _, err := t.Indexes(colnames...); err != nil {
+		return "", err
+	}
+	sets := make([]string, len(colnames))
+	for i, colname := range colnames {
+		sets[i] = pgtypes.QuoteIdentifier(colname) + " = $" + strconv.Itoa(i+1)
+	}
+	return "UPDATE " + pgtypes.QuoteTableName(t.TableName) + " SET " + strings.Join(sets, ", ") + " " + t.WherePK(len(colnames)), nil
+}
+
+%s
+func (t *%sTableType) UpdateByPK(v *%s, colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = %s
+	}
+	sql, err := t.UpdateByPKSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(append(colnames[:len(colnames):len(colnames)], t.PrimaryKey...)...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.UpdateByPKSQL and
+// ({struct-name})TableType.UpdateByPK
+func genUpdateByPKMethods(s *Struct) string {
+	defaults := genStringSlice(nonPKColumnNames(s))
+	sqlDoc := AutoCommentf("UpdateByPKSQL returns an UPDATE statement which sets the columns in %sTable named by colnames, for the row matching the primary key.\n", s.Name)
+	sqlDoc += "//\n"
+	sqlDoc += AutoComment("Parameters for the updated columns come first, followed by the primary key columns. If no column names are provided, all columns outside of the primary key will be updated.")
+	doc := AutoCommentLn("UpdateByPK returns an UPDATE statement for the columns named by colnames, along with parameter oids, format codes and encoders bound to v.")
+	doc += "//\n"
+	doc += AutoComment("The row to update is matched by the primary key fields of v. If no column names are provided, all columns outside of the primary key will be updated.")
+	return fmt.Sprintf(updateByPKMethodsFmt, sqlDoc, s.Name, defaults, s.Name, doc, s.Name, s.Name, defaults)
+}
+
+const deleteByPKMethodsFmt = `
+%s
+func (t *%sTableType) DeleteByPKSQL() string {
+	return "DELETE FROM " + pgtypes.QuoteTableName(t.TableName) + " " + t.WherePK(0)
+}
+
+%s
+func (t *%sTableType) DeleteByPK(v *%s) (*pgtypes.Statement, error) {
+	fe, err := t.Encoders(t.PrimaryKey...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(t.DeleteByPKSQL(), v)
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.DeleteByPKSQL and
+// ({struct-name})TableType.DeleteByPK
+func genDeleteByPKMethods(s *Struct) string {
+	sqlDoc := AutoCommentf("DeleteByPKSQL returns a DELETE statement for the row in %sTable matching the primary key.", s.Name)
+	doc := AutoComment("DeleteByPK returns a DELETE statement along with parameter oids, format codes and encoders bound to the primary key fields of v.")
+	return fmt.Sprintf(deleteByPKMethodsFmt, sqlDoc, s.Name, doc, s.Name, s.Name)
+}
+
+const selectByPKMethodsFmt = `
+%s
+func (t *%sTableType) SelectByPKSQL(colnames ...string) (string, error) {
+	aliases, err := t.Alias(colnames...)
+	if err != nil {
+		return "", err
+	}
+	return "SELECT " + strings.Join(aliases, ", ") + " FROM " + pgtypes.QuoteTableName(t.TableName) + " " + t.WherePK(0), nil
+}
+
+%s
+func (t *%sTableType) SelectByPK(v *%s, colnames ...string) (*pgtypes.Statement, error) {
+	sql, err := t.SelectByPKSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(t.PrimaryKey...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.SelectByPKSQL and
+// ({struct-name})TableType.SelectByPK
+func genSelectByPKMethods(s *Struct) string {
+	sqlDoc := AutoCommentf("SelectByPKSQL returns a SELECT statement for the columns in %sTable named by colnames, for the row matching the primary key.\n", s.Name)
+	sqlDoc += "//\n"
+	sqlDoc += AutoCommentf("Selected columns are aliased (see Alias), so results may be decoded quickly with %s.DecodeRow. If no column names are provided, all columns will be selected.", s.Name)
+	doc := AutoCommentLn("SelectByPK returns a SELECT statement for the columns named by colnames, along with parameter oids, format codes and encoders bound to the primary key fields of v.")
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns will be selected.")
+	return fmt.Sprintf(selectByPKMethodsFmt, sqlDoc, s.Name, doc, s.Name, s.Name)
+}
@@ -0,0 +1,40 @@
+package pgxgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPKColumns(t *testing.T) {
+	for _, tc := range []struct {
+		field string
+		err   string
+	}{
+		{"ID int32 `pgx:\"name:id;type:int4;pk\"`", ""},
+		{"ID string `pgx:\"name:id;type:uuid;pk;null:0\"`", ""},
+		{"ID *int32 `pgx:\"name:id;type:int4;pk\"`", "cannot be null"},
+		{"ID pgx.NullInt32 `pgx:\"name:id;type:int4;pk\"`", "cannot be null"},
+		{"ID sql.NullInt32 `pgx:\"name:id;type:int4;pk\"`", "cannot be null"},
+		{"ID sql.Null[int32] `pgx:\"name:id;type:int4;pk\"`", "cannot be null"},
+		{"ID int32 `pgx:\"name:id;type:int4;pk;null\"`", "cannot be null"},
+		{"ID map[string]int `pgx:\"name:id;type:json;pk\"`", "cannot be compared"},
+	} {
+		src := "package p\n\nimport (\n\t\"database/sql\"\n\n\t\"github.com/wdamron/pgx\"\n)\n\ntype Row struct {\n\t" + tc.field + "\n}\n\nvar _ = sql.ErrNoRows\nvar _ pgx.NullInt32\n"
+		_, err := parseTestFile(t, "test.go", src).Gen()
+		switch {
+		case tc.err == "" && err != nil:
+			t.Errorf("%s: %v", tc.field, err)
+		case tc.err != "" && (err == nil || !strings.Contains(err.Error(), tc.err)):
+			t.Errorf("%s: expected an error containing %q, got %v", tc.field, tc.err, err)
+		}
+	}
+}
+
+func TestGenPKMethods(t *testing.T) {
+	f := genTestFile(t, "package p\n\ntype Row struct {\n\tA int32 `pgx:\"name:a;type:int4;pk\"`\n\tB string `pgx:\"name:b;type:text\"`\n}\n")
+	for _, name := range []string{"WherePK", "UpdateByPKSQL", "UpdateByPK", "DeleteByPKSQL", "DeleteByPK", "SelectByPKSQL", "SelectByPK"} {
+		if findMethod(f, "RowTableType", name) == nil {
+			t.Errorf("RowTableType.%s was not generated", name)
+		}
+	}
+}
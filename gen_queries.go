@@ -0,0 +1,320 @@
+package pgxgen
+
+import (
+	"fmt"
+	"go/format"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/wdamron/astx"
+)
+
+// GenQueries generates and formats a Go function for each of queries, for
+// package pkg, returning bytes or nil if an error has occurred. Path names the
+// queries file within the generated header.
+//
+// Results of each query decode into an existing tagged struct, through its
+// DecodeRow method, or into a generated {query-name}Row struct.
+func GenQueries(pkg, path string, queries []Query) ([]byte, error) {
+	out := fmt.Sprintf(headerFmt, pkg, path)
+	stdImports := map[string]string{}
+	otherImports := map[string]string{DRIVER: "", PGTYPES_PKG: ""}
+	var body string
+	for i := range queries {
+		q := &queries[i]
+		params, args, prelude, err := genQueryParams(q, stdImports, otherImports)
+		if err != nil {
+			return nil, err
+		}
+
+		body += fmt.Sprintf("// %sSQL is the SQL text of the %s query\n", q.Name, q.Name)
+		body += fmt.Sprintf("const %sSQL = %s\n\n", q.Name, genStringLit(q.SQL))
+
+		result := q.Result
+		if len(q.Columns) != 0 {
+			result = q.Name + "Row"
+			row, err := genQueryRow(q, result, stdImports, otherImports)
+			if err != nil {
+				return nil, err
+			}
+			stdImports["errors"] = ""
+			body += row
+		}
+
+		switch q.Kind {
+		case QueryOne:
+			doc := AutoCommentf("%s executes the %s query with q, decoding the first row of the results.\n", q.Name, q.Name)
+			doc += "//\n"
+			doc += AutoComment("If no rows are returned, pgx.ErrNoRows is returned.")
+			body += fmt.Sprintf(queryOneFuncFmt, doc, q.Name, params, result, prelude, q.Name, args, result)
+		case QueryMany:
+			doc := AutoCommentf("%s executes the %s query with q, decoding each row of the results.", q.Name, q.Name)
+			body += fmt.Sprintf(queryManyFuncFmt, doc, q.Name, params, result, prelude, q.Name, args, result, result)
+		case QueryExec:
+			doc := AutoCommentf("%s executes the %s query with e.", q.Name, q.Name)
+			body += fmt.Sprintf(queryExecFuncFmt, doc, q.Name, params, prelude, q.Name, args)
+		}
+	}
+	out += genImports(nil, stdImports, otherImports)
+	out += body
+	return format.Source([]byte(out))
+}
+
+const queryOneFuncFmt = `
+%s
+func %s(q pgtypes.Querier%s) (*%s, error) {
+	%srows, err := q.Query(%sSQL%s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, pgx.ErrNoRows
+	}
+	v := new(%s)
+	if err = v.DecodeRow(rows); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	return v, rows.Err()
+}
+
+`
+
+const queryManyFuncFmt = `
+%s
+func %s(q pgtypes.Querier%s) ([]%s, error) {
+	%srows, err := q.Query(%sSQL%s)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []%s
+	for rows.Next() {
+		var v %s
+		if err = v.DecodeRow(rows); err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+`
+
+const queryExecFuncFmt = `
+%s
+func %s(e pgtypes.Execer%s) (pgx.CommandTag, error) {
+	%sreturn e.Exec(%sSQL%s)
+}
+
+`
+
+// generate the parameter list (with a leading comma) for the function of q,
+// along with the argument list (with a leading comma) of encoders for the
+// parameters, and any statements which must precede the argument list
+func genQueryParams(q *Query, stdImports, otherImports map[string]string) (params, args, prelude string, err error) {
+	for _, p := range q.Params {
+		c, err := newQueryColumn(q, p)
+		if err != nil {
+			return "", "", "", err
+		}
+		addFieldTypeImports(c.StructField.Type, stdImports, otherImports)
+		if c.EncodeOp == Op(0) && !JSONDataTypes[c.Type] {
+			return "", "", "", fmt.Errorf("line %d: no encoder available for parameter %s of query %s (coltype=%s, gotype=%s)", p.Line, p.Name, q.Name, c.Type, c.StructField.Type)
+		}
+		params += ", " + p.Name + " " + c.StructField.Type
+		// nil pointer and invalid null wrapper parameters are encoded as NULL:
+		arg := p.Name + "Enc"
+		switch {
+		case c.StructField.Type[0] == '*':
+			if cond := genOverflowCond(c, "*"+p.Name); cond != "" {
+				prelude += genOverflowCheck(q, p, c, p.Name+" != nil && ("+cond+")", stdImports)
+			}
+			prelude += fmt.Sprintf("var %s pgx.Encoder = %s\nif %s != nil {\n%s = %s\n}\n", arg, genNullEncoderExpr(c), p.Name, arg, genEncoderExpr(c, p.Name))
+		case c.NullValue != "":
+			if cond := genOverflowCond(c, p.Name+"."+c.NullValue); cond != "" {
+				prelude += genOverflowCheck(q, p, c, p.Name+".Valid && ("+cond+")", stdImports)
+			}
+			prelude += fmt.Sprintf("var %s pgx.Encoder = %s\nif %s.Valid {\n%s = %s\n}\n", arg, genNullEncoderExpr(c), p.Name, arg, genEncoderExpr(c, p.Name+"."+c.NullValue))
+		default:
+			if cond := genOverflowCond(c, p.Name); cond != "" {
+				prelude += genOverflowCheck(q, p, c, cond, stdImports)
+			}
+			arg = genEncoderExpr(c, p.Name)
+		}
+		args += ", " + arg
+	}
+	return params, args, prelude, nil
+}
+
+// generate a condition which is true if value, of the value type of c, is out
+// of range for the cast applied by its encoder, or "" if the cast is lossless
+func genOverflowCond(c *Column, value string) string {
+	if !c.EncodeOp.CheckOverflow() {
+		return ""
+	}
+	var bits string
+	switch c.EncodeOp.MaskCast() {
+	case OpCastInt16:
+		bits = "16"
+	case OpCastInt32:
+		bits = "32"
+	case OpCastInt64:
+		bits = "64"
+	case OpCastFloat32:
+		// infinities are representable, but finite values beyond the range of
+		// float32 would become infinite:
+		return fmt.Sprintf("(%s < -math.MaxFloat32 || %s > math.MaxFloat32) && !math.IsInf(%s, 0)", value, value, value)
+	default:
+		return ""
+	}
+	if strings.HasPrefix(strings.TrimPrefix(c.ValueType, "*"), "uint") {
+		return fmt.Sprintf("uint64(%s) > math.MaxInt%s", value, bits)
+	}
+	return fmt.Sprintf("int64(%s) < math.MinInt%s || int64(%s) > math.MaxInt%s", value, bits, value, bits)
+}
+
+// generate a statement which returns an error from the function of q if cond
+// is true, for a parameter p which is out of range for the data type of c
+func genOverflowCheck(q *Query, p QueryField, c *Column, cond string, stdImports map[string]string) string {
+	stdImports["errors"] = ""
+	stdImports["math"] = ""
+	zero := "nil"
+	if q.Kind == QueryExec {
+		zero = `""`
+	}
+	return fmt.Sprintf("if %s {\nreturn %s, errors.New(%q)\n}\n", cond, zero, "parameter "+p.Name+" of query "+q.Name+" is out of range for "+c.Type)
+}
+
+const queryRowFmt = `
+%s
+type %s struct {
+%s}
+
+%s
+var %sScanners = [%d]func(*%s) pgx.Scanner{
+%s}
+
+%s
+func (v *%s) DecodeRow(r *pgx.Rows) error {
+	for i := range r.FieldDescriptions() {
+		vr, ok := r.NextColumn()
+		if !ok {
+			if vr != nil && vr.Err() != nil {
+				return vr.Err()
+			}
+			break
+		}
+		if i >= len(%sScanners) {
+			return errors.New("unexpected column " + vr.Type().Name + " in results of %s")
+		}
+		if err := %sScanners[i](v).Scan(vr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+`
+
+// generate type def for {query-name}Row, with a field for each declared result
+// column of q, along with its scanners and DecodeRow method
+func genQueryRow(q *Query, name string, stdImports, otherImports map[string]string) (string, error) {
+	as := astx.Struct{Name: name}
+	var fields string
+	for _, qc := range q.Columns {
+		c, err := newQueryColumn(q, qc)
+		if err != nil {
+			return "", err
+		}
+		addFieldTypeImports(c.StructField.Type, stdImports, otherImports)
+		f := *c.StructField
+		f.Name = GoName(qc.Name)
+		if f.Name == "" {
+			return "", fmt.Errorf("line %d: no valid field name for result column %s of query %s", qc.Line, qc.Name, q.Name)
+		}
+		for _, other := range as.Fields {
+			if other.Name == f.Name {
+				return "", fmt.Errorf("line %d: duplicate field name %s for result column %s of query %s", qc.Line, f.Name, qc.Name, q.Name)
+			}
+		}
+		as.Fields = append(as.Fields, f)
+		fields += fmt.Sprintf("%s %s `%s`\n", f.Name, f.Type, f.Tag)
+	}
+	s := NewStruct(&as)
+	var scanners string
+	for _, c := range s.Columns {
+		scanner, err := genFieldScanner(s, &c)
+		if err != nil {
+			return "", fmt.Errorf("line %d: query %s: %v", q.Line, q.Name, err)
+		}
+		scanners += scanner
+	}
+	scannersVar := strings.ToLower(name[:1]) + name[1:]
+	typeDoc := AutoCommentf("%s holds a row of the results of the %s query.", name, q.Name)
+	scannersDoc := AutoCommentf("%sScanners contains unbound scanners for the columns of %s, in the order of the %s query results.", scannersVar, name, q.Name)
+	decodeDoc := AutoCommentf("DecodeRow decodes a single row/result from r into v. Columns are decoded positionally, in the order of the %s query results.\n", q.Name)
+	decodeDoc += "//\n"
+	decodeDoc += AutoComment("If an error is returned, the caller should call Rows.Close()")
+	return fmt.Sprintf(queryRowFmt,
+		typeDoc, name, fields,
+		scannersDoc, scannersVar, len(s.Columns), name, scanners,
+		decodeDoc, name, scannersVar, q.Name, scannersVar), nil
+}
+
+// newQueryColumn creates a Column for a declared parameter or result column of
+// q, choosing a Go type with FieldType if none was declared
+func newQueryColumn(q *Query, qf QueryField) (*Column, error) {
+	coltype := NormalizeDataType(qf.Type)
+	if DataTypeNames[coltype] == "" {
+		return nil, fmt.Errorf("line %d: unsupported data type for %s of query %s: %s", qf.Line, qf.Name, q.Name, qf.Type)
+	}
+	gotype := qf.GoType
+	if gotype == "" {
+		if gotype = FieldType(coltype, false); gotype == "" {
+			return nil, fmt.Errorf("line %d: no Go type available for %s of query %s (coltype=%s)", qf.Line, qf.Name, q.Name, coltype)
+		}
+	}
+	// the declared type is kept within the tag, for the precision and scale of
+	// numeric types:
+	tag := fmt.Sprintf("%s:%s", ColumnTagName, strconv.Quote(ColumnNameKey+":"+qf.Name+";"+ColumnTypeKey+":"+qf.Type))
+	return NewColumn(&astx.StructField{Name: qf.Name, Type: gotype, Tag: reflect.StructTag(tag)}), nil
+}
+
+// add the imports needed by the Go type gotype
+func addFieldTypeImports(gotype string, stdImports, otherImports map[string]string) {
+	if strings.Contains(gotype, "time.") {
+		stdImports["time"] = ""
+	}
+	if strings.Contains(gotype, "uuid.") {
+		otherImports[UUID_PKG] = ""
+	}
+	if strings.Contains(gotype, "sql.") {
+		stdImports["database/sql"] = ""
+	}
+	if strings.Contains(gotype, "json.") {
+		stdImports["encoding/json"] = ""
+	}
+	if strings.Contains(gotype, "big.") {
+		stdImports["math/big"] = ""
+	}
+	if strings.Contains(gotype, "net.") {
+		stdImports["net"] = ""
+	}
+	if strings.Contains(gotype, "netip.") {
+		stdImports["net/netip"] = ""
+	}
+}
+
+// format s as a Go string literal, preferring a raw string literal
+func genStringLit(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,66 @@
+package pgxgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenQueriesExample(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join("example", "queries.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	queries, err := ParseQueries(string(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := GenQueries("example", "queries.sql", queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(filepath.Join("example", "queries_pgxgen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gen, want) {
+		t.Fatal("example/queries_pgxgen.go is out of date; regenerate it with pgx-gen queries example/queries.sql")
+	}
+}
+
+func TestGenQueriesOverflow(t *testing.T) {
+	queries, err := ParseQueries(`
+-- name: SetCounts :exec
+-- param: small int2 int
+-- param: medium int4 *uint32
+-- param: total int8 sql.NullInt64
+-- param: ratio real float64
+-- param: id int4 int32
+UPDATE counts SET a = $1, b = $2, c = $3, d = $4 WHERE e = $5;
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err := GenQueries("p", "counts.sql", queries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src := string(gen)
+	for _, want := range []string{
+		"if int64(small) < math.MinInt16 || int64(small) > math.MaxInt16 {\n\t\treturn \"\", errors.New(\"parameter small of query SetCounts is out of range for int2\")",
+		"if medium != nil && (uint64(*medium) > math.MaxInt32) {",
+		"if (ratio < -math.MaxFloat32 || ratio > math.MaxFloat32) && !math.IsInf(ratio, 0) {",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("missing %q in\n%s", want, src)
+		}
+	}
+	// lossless casts are not checked
+	for _, param := range []string{"total", "id"} {
+		if strings.Contains(src, "parameter "+param+" of query") {
+			t.Errorf("unexpected range check for parameter %s in\n%s", param, src)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package pgxgen
+
+import (
+	"fmt"
+)
+
+const returningSQLMethodFmt = `
+%s
+func (t *%sTableType) ReturningSQL(colnames ...string) (string, error) {
+	aliases, err := t.Alias(colnames...)
+	if err != nil {
+		return "", err
+	}
+	return "RETURNING " + strings.Join(aliases, ", "), nil
+}
+
+`
+
+// generate method def for ({struct-name})TableType.ReturningSQL
+func genReturningSQLMethod(s *Struct) string {
+	doc := AutoCommentf("ReturningSQL returns a RETURNING clause for the columns in %sTable named by colnames.\n", s.Name)
+	doc += "//\n"
+	doc += AutoCommentf("Returned columns are aliased (see Alias), so results may be decoded quickly with %s.DecodeRow. If no column names are provided, all columns will be returned.", s.Name)
+	return fmt.Sprintf(returningSQLMethodFmt, doc, s.Name)
+}
+
+const insertReturningMethodFmt = `
+%s
+func (v *%s) InsertReturning(returning []string, colnames ...string) (*pgtypes.Statement, error) {
+	ret, err := %sTable.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	st, err := v.InsertArgs(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	st.SQL += " " + ret
+	return st, nil
+}
+
+`
+
+// generate method def for {struct-name}.InsertReturning
+func genInsertReturningMethod(s *Struct) string {
+	doc := AutoCommentLn("InsertReturning is like InsertArgs, with a RETURNING clause for the columns named by returning appended to the statement.")
+	doc += "//\n"
+	doc += AutoCommentf("Pass the statement to %s.QueryReturning to write the returned values back into v. If no returned column names are provided, all columns will be returned.", s.Name)
+	return fmt.Sprintf(insertReturningMethodFmt, doc, s.Name, s.Name)
+}
+
+const updateByPKReturningMethodFmt = `
+%s
+func (t *%sTableType) UpdateByPKReturning(v *%s, returning []string, colnames ...string) (*pgtypes.Statement, error) {
+	ret, err := t.ReturningSQL(returning...)
+	if err != nil {
+		return nil, err
+	}
+	st, err := t.UpdateByPK(v, colnames...)
+	if err != nil {
+		return nil, err
+	}
+	st.SQL += " " + ret
+	return st, nil
+}
+
+`
+
+// generate method def for ({struct-name})TableType.UpdateByPKReturning
+func genUpdateByPKReturningMethod(s *Struct) string {
+	doc := AutoCommentLn("UpdateByPKReturning is like UpdateByPK, with a RETURNING clause for the columns named by returning appended to the statement.")
+	doc += "//\n"
+	doc += AutoCommentf("Pass the statement to %s.QueryReturning to write the returned values back into v. If no returned column names are provided, all columns will be returned.", s.Name)
+	return fmt.Sprintf(updateByPKReturningMethodFmt, doc, s.Name, s.Name)
+}
+
+const queryReturningMethodFmt = `
+%s
+func (v *%s) QueryReturning(q pgtypes.Querier, st *pgtypes.Statement) error {
+	rows, err := q.Query(st.SQL, st.Params()...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+	if err = v.DecodeRow(rows); err != nil {
+		return err
+	}
+	rows.Close()
+	return rows.Err()
+}
+
+`
+
+// generate method def for {struct-name}.QueryReturning
+func genQueryReturningMethod(s *Struct) string {
+	doc := AutoCommentLn("QueryReturning executes st with q, decoding the first row of the results into v.")
+	doc += "//\n"
+	doc += AutoCommentf("The statement should end with a RETURNING clause for aliased columns (see %sTable.ReturningSQL). If no rows are returned, pgx.ErrNoRows is returned.", s.Name)
+	return fmt.Sprintf(queryReturningMethodFmt, doc, s.Name)
+}
@@ -0,0 +1,114 @@
+package pgxgen
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wdamron/astx"
+)
+
+// column types which each database/sql null wrapper type must support
+var sqlNullSupported = map[string][]string{
+	"sql.NullBool":    {"bool"},
+	"sql.NullByte":    {"int2", "int4", "int8"},
+	"sql.NullInt16":   {"int2", "int4", "int8"},
+	"sql.NullInt32":   {"int2", "int4", "int8"},
+	"sql.NullInt64":   {"int2", "int4", "int8"},
+	"sql.NullFloat64": {"float8", "float"},
+	"sql.NullString":  {"text", "varchar", "bytea"},
+	"sql.NullTime":    {"date", "timestamp", "timestampTz"},
+}
+
+// generate code for a struct with a field for each pair of field type and
+// column type within fields
+func genSQLNullStruct(t *testing.T, dir string, fields ...[2]string) (src string, gen []byte, err error) {
+	src = "package sqlnull\n\nimport \"database/sql\"\n\nvar _ sql.NullBool\n\ntype T struct {\n"
+	for i, f := range fields {
+		src += fmt.Sprintf("\tF%d %s `pgx:\"name:f%d;type:%s\"`\n", i, f[0], i, f[1])
+	}
+	src += "}\n"
+	path := filepath.Join(dir, "sqlnull.go")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	af, err := astx.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gen, err = NewFile(af).Gen()
+	return src, gen, err
+}
+
+func TestGenSQLNullTypes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compilation of generated code in short mode")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	// the generated packages are built within this module, so that pgtypes
+	// resolves to the package under test:
+	dir, err := os.MkdirTemp(".", "sqlnull")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	coltypes := []string{}
+	for coltype := range DataTypeNames {
+		coltypes = append(coltypes, coltype)
+	}
+	sort.Strings(coltypes)
+	ftypes := []string{}
+	for ftype := range SQLNullTypes {
+		ftypes = append(ftypes, ftype)
+	}
+	sort.Strings(ftypes)
+
+	for _, ftype := range ftypes {
+		for _, coltype := range sqlNullSupported[ftype] {
+			if _, _, err := genSQLNullStruct(t, dir, [2]string{ftype, coltype}); err != nil {
+				t.Errorf("%s as %s: %v", ftype, coltype, err)
+			}
+		}
+		// every pair which generates without errors must compile:
+		fields := [][2]string{}
+		for _, coltype := range coltypes {
+			if _, _, err := genSQLNullStruct(t, dir, [2]string{ftype, coltype}); err == nil {
+				fields = append(fields, [2]string{ftype, coltype})
+			} else if !strings.HasPrefix(err.Error(), "no ") {
+				t.Errorf("%s as %s: unexpected error: %v", ftype, coltype, err)
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		src, gen, err := genSQLNullStruct(t, dir, fields...)
+		if err != nil {
+			t.Errorf("%s: %v", ftype, err)
+			continue
+		}
+		pkgdir := filepath.Join(dir, strings.NewReplacer(".", "_").Replace(ftype))
+		if err := os.Mkdir(pkgdir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgdir, "sqlnull.go"), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgdir, "sqlnull_pgxgen.go"), gen, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.Remove(filepath.Join(dir, "sqlnull.go"))
+
+	out, err := exec.Command(gobin, "build", "./"+filepath.ToSlash(dir)+"/...").CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, out)
+	}
+}
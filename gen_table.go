@@ -4,6 +4,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/wdamron/pgx-gen/pgtypes"
 )
 
 // generate var def for {struct-name}Table
@@ -32,6 +35,22 @@ func genTable(s *Struct) (string, error) {
 	out += genFormatArray(s)
 	// include ordered list of column oids:
 	out += genOidArray(s)
+	// include ordered list of column merge rules:
+	merges, err := genMergeArray(s)
+	if err != nil {
+		return "", err
+	}
+	out += merges
+	// include table name:
+	out += fmt.Sprintf("TableName: \"%s\",\n", s.Table)
+	// include ordered list of primary key column names:
+	out += genPKArray(s)
+	// include ordered list of conflict target column names:
+	conflict, err := genConflictTargetArray(s)
+	if err != nil {
+		return "", err
+	}
+	out += conflict
 
 	return out + "}\n\n", nil
 }
@@ -42,7 +61,7 @@ func genFieldEncoderArray(s *Struct) (string, error) {
 	for _, c := range s.Columns {
 		f := c.StructField
 		op := c.EncodeOp
-		if op == Op(0) && c.Type != "json" {
+		if op == Op(0) && !JSONDataTypes[c.Type] {
 			return "", fmt.Errorf("no encoder available for field: %s.%s (coltype=%s, fieldtype=%s)", s.Name, f.Name, c.Type, f.Type)
 		}
 		dtName := DataTypeNames[c.Type]
@@ -52,43 +71,200 @@ func genFieldEncoderArray(s *Struct) (string, error) {
 		if f.Type == "" {
 			return "", fmt.Errorf("no type defined for field: %s.%s", s.Name, f.Name)
 		}
+		if err := checkJSONCodec(s, &c); err != nil {
+			return "", err
+		}
+		if err := checkLocation(s, &c); err != nil {
+			return "", err
+		}
 
 		out += fmt.Sprintf("// Encode v.%s as %s\n", f.Name, c.Type)
 		out += fmt.Sprintf("func(v *%s) pgx.Encoder {\n", s.Name)
-		deref := ""
-		if f.Type[0] == '*' {
-			deref = "*"
-		}
+		value := "v." + f.Name
 		switch {
-		default:
-			if c.Type != "json" {
-				var castPrefix, castSuffix string
-				if op.MaskCast() != Op(0) {
-					castPrefix, castSuffix = op.FormatCast()+"(", ")"
-				}
-				out += fmt.Sprintf("return pgtypes.%sEncoder(%s%sv.%s%s)\n", dtName, castPrefix, deref, f.Name, castSuffix)
-			} else {
-				switch f.Type {
-				case "string", "*string":
-					out += fmt.Sprintf("return pgtypes.JSONEncoderString(%sv.%s)\n", deref, f.Name)
-				case "[]byte", "*[]byte":
-					out += fmt.Sprintf("return pgtypes.JSONEncoderBytes(%sv.%s)\n", deref, f.Name)
-				default:
-					out += fmt.Sprintf("return pgtypes.JSONEncoder(v.%s)\n", f.Name)
-				}
-			}
-		case op.CustomEncode():
-			out += fmt.Sprintf("return v.%s\n", f.Name)
-		case op.HstoreMapEncode():
-			out += fmt.Sprintf("return pgtypes.HstoreMapEncoder(%sv.%s)\n", deref, f.Name)
-		case op.UuidStringEncode():
-			out += fmt.Sprintf("return pgtypes.UUIDEncoderString(%sv.%s)\n", deref, f.Name)
+		case f.Type[0] == '*':
+			out += fmt.Sprintf("if %s == nil {\nreturn %s\n}\n", value, genNullEncoderExpr(&c))
+		case c.NullValue != "":
+			out += fmt.Sprintf("if !%s.Valid {\nreturn %s\n}\n", value, genNullEncoderExpr(&c))
+			value += "." + c.NullValue
 		}
+		out += "return " + genEncoderExpr(&c, value) + "\n"
 		out += "},\n"
 	}
 	return out + "},\n", nil
 }
 
+// generate an expression which encodes value, a Go expression with the value
+// type of c, as the data type of c (see genFieldEncoderArray)
+func genEncoderExpr(c *Column, value string) string {
+	ftype := c.ValueType
+	op := c.EncodeOp
+	deref := ""
+	if ftype[0] == '*' {
+		deref = "*"
+	}
+	switch {
+	default:
+		dtName := DataTypeNames[c.Type]
+		if !JSONDataTypes[c.Type] {
+			var castPrefix, castSuffix string
+			if op.MaskCast() != Op(0) {
+				castPrefix, castSuffix = op.FormatCast()+"(", ")"
+			}
+			return fmt.Sprintf("pgtypes.%sEncoder(%s%s%s%s)", dtName, castPrefix, deref, value, castSuffix)
+		}
+		if suffix := jsonFuncSuffix(c.Type, ftype); suffix != "" {
+			return fmt.Sprintf("pgtypes.%sEncoder%s(%s%s)", dtName, suffix, deref, value)
+		}
+		if codec := c.JSONCodec(); codec != "" {
+			return fmt.Sprintf("pgtypes.%sEncoderCodec(%s, %q)", dtName, value, codec)
+		}
+		return fmt.Sprintf("pgtypes.%sEncoder(%s)", dtName, value)
+	case op.CustomEncode():
+		return value
+	case op.HstoreMapEncode():
+		return fmt.Sprintf("pgtypes.HstoreMapEncoder(%s%s)", deref, value)
+	case op.UuidStringEncode():
+		return fmt.Sprintf("pgtypes.%sEncoderString(%s%s)", DataTypeNames[c.Type], deref, value)
+	case op.InfinityEncode():
+		if loc := c.Location(); loc != "" && c.Type == "timestamp" {
+			return fmt.Sprintf("pgtypes.%sEncoderInfinityLocation(%s%s, %q)", DataTypeNames[c.Type], deref, value, loc)
+		}
+		return fmt.Sprintf("pgtypes.%sEncoderInfinity(%s%s)", DataTypeNames[c.Type], deref, value)
+	case c.Location() != "" && c.Type == "timestamp":
+		return fmt.Sprintf("pgtypes.%sEncoderLocation(%s%s, %q)", DataTypeNames[c.Type], deref, value, c.Location())
+	case op.TimeOfDayEncode():
+		return fmt.Sprintf("pgtypes.%sEncoderTimeOfDay(%s%s)", DataTypeNames[c.Type], deref, value)
+	case op.DurationEncode():
+		return fmt.Sprintf("pgtypes.%sEncoderDuration(%s%s)", DataTypeNames[c.Type], deref, value)
+	case op.MultiDimEncode():
+		return fmt.Sprintf("pgtypes.%sEncoderMulti(%s%s)", DataTypeNames[c.Type], deref, value)
+	case op.NullElemEncode():
+		return fmt.Sprintf("pgtypes.%sEncoder%s(%s%s)", DataTypeNames[c.Type], nullElemFuncSuffix(ftype), deref, value)
+	case op.NetEncode():
+		if !op.DerefPass() {
+			deref = ""
+		}
+		return fmt.Sprintf("pgtypes.%sEncoder%s(%s%s)", DataTypeNames[c.Type], netFuncSuffix(ftype), deref, value)
+	case op.NumericEncode():
+		if !op.DerefPass() {
+			deref = ""
+		}
+		precision, scale := c.Numeric()
+		return fmt.Sprintf("pgtypes.%sEncoder%s(%s%s, %d, %d)", DataTypeNames[c.Type], numericFuncSuffix(ftype), deref, value, precision, scale)
+	}
+}
+
+// check the time zone option of c, which is only valid for date and timestamp
+// columns with time.Time or pgtypes.InfinityTime field types (dates are encoded
+// from their calendar date, so the option only applies when scanning dates)
+func checkLocation(s *Struct, c *Column) error {
+	loc, ok := c.Spec[ColumnTZKey]
+	if !ok {
+		return nil
+	}
+	if c.Type != "date" && c.Type != "timestamp" {
+		return fmt.Errorf("tz option is only valid for date and timestamp columns: %s.%s (coltype=%s)", s.Name, c.StructField.Name, c.Type)
+	}
+	if loc == "1" || loc == "0" {
+		return fmt.Errorf("tz option requires a location name: %s.%s", s.Name, c.StructField.Name)
+	}
+	switch strings.TrimPrefix(c.ValueType, "*") {
+	case "time.Time", "pgtypes.InfinityTime":
+	default:
+		return fmt.Errorf("tz option is only valid for time.Time and pgtypes.InfinityTime fields: %s.%s (fieldtype=%s)", s.Name, c.StructField.Name, c.StructField.Type)
+	}
+	if _, err := time.LoadLocation(loc); err != nil {
+		return fmt.Errorf("tz option has an unknown location: %s.%s (tz=%s)", s.Name, c.StructField.Name, loc)
+	}
+	return nil
+}
+
+// check the json codec option of c, which is only valid for json, jsonb, json[]
+// and jsonb[] columns with field types that are marshaled (see genEncoderExpr)
+func checkJSONCodec(s *Struct, c *Column) error {
+	codec, ok := c.Spec[ColumnJSONKey]
+	if !ok {
+		return nil
+	}
+	if !JSONDataTypes[c.Type] {
+		return fmt.Errorf("json codec option is only valid for json, jsonb, json[] and jsonb[] columns: %s.%s (coltype=%s)", s.Name, c.StructField.Name, c.Type)
+	}
+	if codec == "1" || codec == "0" {
+		return fmt.Errorf("json codec option requires a codec name: %s.%s", s.Name, c.StructField.Name)
+	}
+	if jsonFuncSuffix(c.Type, c.ValueType) != "" {
+		return fmt.Errorf("json codec option is not valid for fields which are not marshaled: %s.%s (fieldtype=%s)", s.Name, c.StructField.Name, c.StructField.Type)
+	}
+	return nil
+}
+
+// suffix of the pgtypes json/jsonb encoder/scanner funcs for fields of type
+// ftype which hold encoded JSON, or "" if ftype is marshaled (e.g. string ->
+// String for json, []json.RawMessage -> Raw for json[])
+func jsonFuncSuffix(coltype, ftype string) string {
+	ftype = strings.TrimPrefix(ftype, "*")
+	if strings.HasSuffix(coltype, "[]") {
+		switch ftype {
+		case "[]string":
+			return "String"
+		case "[]json.RawMessage":
+			return "Raw"
+		}
+		return ""
+	}
+	switch ftype {
+	case "string":
+		return "String"
+	case "[]byte", "json.RawMessage":
+		return "Bytes"
+	}
+	return ""
+}
+
+// suffix of the pgtypes numeric encoder/scanner funcs for the Go type ftype
+// (e.g. *big.Rat -> Rat, int64 -> Int64, []string -> String)
+func numericFuncSuffix(ftype string) string {
+	ftype = strings.TrimPrefix(strings.TrimPrefix(ftype, "*"), "[]")
+	return strings.Title(strings.TrimPrefix(strings.TrimPrefix(ftype, "*"), "big."))
+}
+
+// suffix of the pgtypes bytea scanner funcs for the Go type ftype, since
+// OpCastString does not distinguish string fields from []byte fields (e.g.
+// string -> String, []byte -> "")
+func byteaFuncSuffix(coltype, ftype string) string {
+	if coltype == "bytea" && strings.TrimPrefix(ftype, "*") == "string" {
+		return "String"
+	}
+	return ""
+}
+
+// suffix of the pgtypes inet/cidr encoder/scanner funcs for the Go type ftype
+// (e.g. net.IP -> IP, *net.IPNet -> IPNet, []netip.Prefix -> Prefix)
+func netFuncSuffix(ftype string) string {
+	ftype = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(ftype, "*"), "[]"), "*")
+	return strings.TrimPrefix(strings.TrimPrefix(ftype, "netip."), "net.")
+}
+
+// suffix of the pgtypes array encoder/scanner funcs for arrays with NULL
+// elements, for the slice type ftype (e.g. []*int32 -> Ptr, []pgx.NullInt32 ->
+// Null)
+func nullElemFuncSuffix(ftype string) string {
+	if strings.HasPrefix(strings.TrimPrefix(strings.TrimPrefix(ftype, "*"), "[]"), "*") {
+		return "Ptr"
+	}
+	return "Null"
+}
+
+// generate an expression which encodes NULL as the data type of c
+func genNullEncoderExpr(c *Column) string {
+	format := 0
+	if BinaryDataTypes[DataTypeNames[c.Type]] {
+		format = 1
+	}
+	return fmt.Sprintf("pgtypes.NullEncoder(pgtypes.%sOid, %d)", DataTypeNames[c.Type], format)
+}
+
 // include ordered list of field-scanner funcs (see genTable)
 func genFieldScannerArray(s *Struct) (string, error) {
 	count := len(s.Columns)
@@ -108,7 +284,7 @@ func genFieldScanner(s *Struct, c *Column) (string, error) {
 	f := c.StructField
 	coltype := c.Type
 	op := c.DecodeOp
-	if op == Op(0) && c.Type != "json" {
+	if op == Op(0) && !JSONDataTypes[c.Type] {
 		return "", fmt.Errorf("no scanner available for field: %s.%s (coltype=%s, fieldtype=%s)", s.Name, f.Name, c.Type, f.Type)
 	}
 	dtName := DataTypeNames[coltype]
@@ -121,42 +297,86 @@ func genFieldScanner(s *Struct, c *Column) (string, error) {
 	// TODO(wd): check overflow, when necessary
 	out := fmt.Sprintf("// Decode column %s::%s into v.%s\n", c.Name, coltype, f.Name)
 	out += fmt.Sprintf("func(v *%s) pgx.Scanner {\n", s.Name)
+	// database/sql null wrappers are scanned through their value field:
+	ftype, target := f.Type, "v."+f.Name
+	if c.NullValue != "" {
+		ftype, target = c.ValueType, target+"."+c.NullValue
+	}
 	takeAddr := ""
-	if f.Type[0] != '*' {
+	if ftype[0] != '*' {
 		takeAddr = "&"
 	}
+	var ret string
 	switch {
 	default:
-		if c.Type != "json" {
+		if !JSONDataTypes[c.Type] {
 			if op.MaskCast() == Op(0) {
-				out += fmt.Sprintf("return pgtypes.%sScanner(%sv.%s)\n", dtName, takeAddr, f.Name)
+				ret = fmt.Sprintf("pgtypes.%sScanner%s(%s%s)", dtName, byteaFuncSuffix(coltype, ftype), takeAddr, target)
 			} else {
 				cast := op.FormatCast()
 				if cast == "" {
 					return "", fmt.Errorf("no scanner available for field: %s.%s (coltype=%s, fieldtype=%s)", s.Name, f.Name, c.Type, f.Type)
 				}
-				out += fmt.Sprintf("return pgtypes.Into%s(%sv.%s)\n", strings.Title(cast), takeAddr, f.Name)
+				ret = fmt.Sprintf("pgtypes.Into%s(%s%s)", strings.Title(cast), takeAddr, target)
 			}
 		} else {
-			switch f.Type {
-			case "string", "*string":
-				out += fmt.Sprintf("return pgtypes.JSONScannerString(%sv.%s)\n", takeAddr, f.Name)
-			case "[]byte", "*[]byte":
-				out += fmt.Sprintf("return pgtypes.JSONScannerBytes(%sv.%s)\n", takeAddr, f.Name)
+			switch suffix := jsonFuncSuffix(coltype, ftype); {
+			case suffix == "Bytes" && strings.HasSuffix(ftype, "json.RawMessage"):
+				ret = fmt.Sprintf("pgtypes.%sScannerBytes((*[]byte)(%s%s))", dtName, takeAddr, target)
+			case suffix != "":
+				ret = fmt.Sprintf("pgtypes.%sScanner%s(%s%s)", dtName, suffix, takeAddr, target)
+			case c.JSONCodec() != "":
+				ret = fmt.Sprintf("pgtypes.%sScannerCodec(%s%s, %q)", dtName, takeAddr, target, c.JSONCodec())
 			default:
-				out += fmt.Sprintf("return pgtypes.JSONScanner(%sv.%s)\n", takeAddr, f.Name)
+				ret = fmt.Sprintf("pgtypes.%sScanner(%s%s)", dtName, takeAddr, target)
 			}
 		}
 	case op.CustomScan():
-		out += fmt.Sprintf("return %sv.%s\n", takeAddr, f.Name)
+		ret = fmt.Sprintf("%s%s", takeAddr, target)
 	case op.HstoreMapDecode():
-		out += fmt.Sprintf("return pgtypes.HstoreMapScanner(%sv.%s)\n", takeAddr, f.Name)
+		ret = fmt.Sprintf("pgtypes.HstoreMapScanner(%s%s)", takeAddr, target)
 	case op.UuidDecode():
 		if op.UuidStringDecode() {
-			out += fmt.Sprintf("return pgtypes.UUIDScannerString(%sv.%s)\n", takeAddr, f.Name)
+			ret = fmt.Sprintf("pgtypes.%sScannerString(%s%s)", dtName, takeAddr, target)
 		} else {
-			out += fmt.Sprintf("return pgtypes.UUIDScanner(%sv.%s)\n", takeAddr, f.Name)
+			ret = fmt.Sprintf("pgtypes.%sScanner(%s%s)", dtName, takeAddr, target)
 		}
+	case op.InfinityDecode():
+		if loc := c.Location(); loc != "" {
+			ret = fmt.Sprintf("pgtypes.%sScannerInfinityLocation(%s%s, %q)", dtName, takeAddr, target, loc)
+		} else {
+			ret = fmt.Sprintf("pgtypes.%sScannerInfinity(%s%s)", dtName, takeAddr, target)
+		}
+	case c.Location() != "" && op.MaskCast() == Op(0):
+		ret = fmt.Sprintf("pgtypes.%sScannerLocation(%s%s, %q)", dtName, takeAddr, target, c.Location())
+	case op.TimeOfDayDecode():
+		ret = fmt.Sprintf("pgtypes.%sScannerTimeOfDay(%s%s)", dtName, takeAddr, target)
+	case op.DurationDecode():
+		ret = fmt.Sprintf("pgtypes.%sScannerDuration(%s%s)", dtName, takeAddr, target)
+	case op.MultiDimDecode():
+		ret = fmt.Sprintf("pgtypes.%sScannerMulti(%s%s)", dtName, takeAddr, target)
+	case op.NullElemDecode():
+		ret = fmt.Sprintf("pgtypes.%sScanner%s(%s%s)", dtName, nullElemFuncSuffix(ftype), takeAddr, target)
+	case op.NetDecode():
+		ret = fmt.Sprintf("pgtypes.%sScanner%s(%s%s)", dtName, netFuncSuffix(ftype), takeAddr, target)
+	case op.NumericDecode():
+		ret = fmt.Sprintf("pgtypes.%sScanner%s(%s%s)", dtName, numericFuncSuffix(ftype), takeAddr, target)
+	}
+	switch {
+	case f.Type[0] == '*':
+		// NULL clears the pointer field, otherwise a new value is allocated:
+		out += fmt.Sprintf("return pgtypes.NullableScanner(func() { v.%s = nil }, func() pgx.Scanner {\n", f.Name)
+		out += fmt.Sprintf("v.%s = new(%s)\n", f.Name, f.Type[1:])
+		out += "return " + ret + "\n"
+		out += "})\n"
+	case c.NullValue != "":
+		// NULL clears the null wrapper field, otherwise it is marked valid:
+		out += fmt.Sprintf("return pgtypes.NullableScanner(func() { v.%s = %s{} }, func() pgx.Scanner {\n", f.Name, f.Type)
+		out += fmt.Sprintf("v.%s.Valid = true\n", f.Name)
+		out += "return " + ret + "\n"
+		out += "})\n"
+	default:
+		out += "return " + ret + "\n"
 	}
 	out += "},\n"
 
@@ -192,11 +412,55 @@ func genAliasArray(s *Struct) string {
 			hexIdx = "0" + hexIdx
 		}
 		shortName := "__" + hexIdx
-		out += fmt.Sprintf("\"%s as %s::%s\",\n", c.Name, shortName, c.Type)
+		out += genStringLit(pgtypes.QuoteIdentifier(c.Name)+"::"+c.Type+" AS "+shortName) + ",\n"
 	}
 	return out + "},\n"
 }
 
+// include ordered list of primary key column names (see genTable)
+func genPKArray(s *Struct) string {
+	pk := s.PKColumns()
+	if len(pk) == 0 {
+		return ""
+	}
+	out := "PrimaryKey: []string{\n"
+	for _, c := range pk {
+		out += fmt.Sprintf("\"%s\",\n", c.Name)
+	}
+	return out + "},\n"
+}
+
+// include ordered list of column merge rules (see genTable)
+func genMergeArray(s *Struct) (string, error) {
+	out := fmt.Sprintf("Merges: [%d]string{", len(s.Columns))
+	for i, c := range s.Columns {
+		merge := c.Merge()
+		switch merge {
+		case MergeOverwrite, MergeKeep, MergeCoalesce, MergeSkip:
+		default:
+			return "", fmt.Errorf("invalid merge rule for field: %s.%s (merge=%s)", s.Name, c.StructField.Name, merge)
+		}
+		out += fmt.Sprintf("\"%s\"", merge)
+		if i != len(s.Columns)-1 {
+			out += ", "
+		}
+	}
+	return out + "},\n", nil
+}
+
+// include ordered list of conflict target column names (see genTable)
+func genConflictTargetArray(s *Struct) (string, error) {
+	target, err := s.ConflictTarget()
+	if err != nil || len(target) == 0 {
+		return "", err
+	}
+	out := "ConflictTarget: []string{\n"
+	for _, name := range target {
+		out += fmt.Sprintf("\"%s\",\n", name)
+	}
+	return out + "},\n", nil
+}
+
 // include ordered list of column oids (see genTable)
 func genOidArray(s *Struct) string {
 	out := fmt.Sprintf("Oids: [%d]pgx.Oid{\n", len(s.Columns))
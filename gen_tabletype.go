@@ -32,6 +32,18 @@ func genTableType(s *Struct) string {
 	out += AutoCommentLn("Oids contains an ordered list of column oid codes (corresponding with Postgres types)")
 	out += fmt.Sprintf("Oids        [%d]pgx.Oid\n", cols)
 
+	out += AutoCommentLn("Merges contains an ordered list of column merge rules for upserts (overwrite, keep, coalesce or skip)")
+	out += fmt.Sprintf("Merges      [%d]string\n", cols)
+
+	out += AutoCommentLn("TableName is the name of the table, as used when generating SQL")
+	out += "TableName   string\n"
+
+	out += AutoCommentLn("PrimaryKey contains an ordered list of primary key column names")
+	out += "PrimaryKey  []string\n"
+
+	out += AutoCommentLn("ConflictTarget contains an ordered list of column names used as the ON CONFLICT target for upserts")
+	out += "ConflictTarget []string\n"
+
 	out += "}\n\n"
 
 	return out
@@ -3,6 +3,9 @@ package pgxgen
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
+
+	"github.com/wdamron/pgx-gen/pgtypes"
 )
 
 // generate method def for ({struct-name})TableType.Index
@@ -76,9 +79,7 @@ func genAliasMethod(s *Struct) string {
 func genAliasAllMethod(s *Struct) string {
 	out := AutoCommentLn("AliasAll aliases column names as hex-encoded indexes, for faster look-ups during decoding")
 	out += fmt.Sprintf("func (t *%sTableType) AliasAll() string {\n", s.Name)
-	out += "return \""
-
-	lastIdx := len(s.Columns) - 1
+	aliases := make([]string, len(s.Columns))
 	for i, c := range s.Columns {
 		// 256 columns are supported, for now:
 		hexIdx := hex.EncodeToString([]byte{byte(i)})
@@ -86,11 +87,8 @@ func genAliasAllMethod(s *Struct) string {
 			hexIdx = "0" + hexIdx
 		}
 		shortName := "__" + hexIdx
-		out += fmt.Sprintf("%s as %s::%s", c.Name, shortName, c.Type)
-		if i != lastIdx {
-			out += ", "
-		}
+		aliases[i] = pgtypes.QuoteIdentifier(c.Name) + "::" + c.Type + " AS " + shortName
 	}
 
-	return out + "\"\n}\n\n"
+	return out + "return " + genStringLit(strings.Join(aliases, ", ")) + "\n}\n\n"
 }
@@ -0,0 +1,98 @@
+package pgxgen
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wdamron/astx"
+)
+
+// parseTestFile parses the Go source src as a file named name
+func parseTestFile(t *testing.T, name, src string) *File {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	af, err := astx.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewFile(af)
+}
+
+// genTestFile generates code for the Go source src, returning the syntax tree
+// of the generated code
+func genTestFile(t *testing.T, src string) *ast.File {
+	t.Helper()
+	gen, err := parseTestFile(t, "test.go", src).Gen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parser.ParseFile(token.NewFileSet(), "test_pgxgen.go", gen, 0)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, gen)
+	}
+	return f
+}
+
+// findMethod returns the declaration of the method of the named receiver type
+// within f, or nil if no such method is declared
+func findMethod(f *ast.File, recv, name string) *ast.FuncDecl {
+	for _, d := range f.Decls {
+		fd, ok := d.(*ast.FuncDecl)
+		if !ok || fd.Name.Name != name || fd.Recv == nil {
+			continue
+		}
+		typ := fd.Recv.List[0].Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		if ident, ok := typ.(*ast.Ident); ok && ident.Name == recv {
+			return fd
+		}
+	}
+	return nil
+}
+
+// checkGolden fails if the code generated from the Go source at srcpath does
+// not match the contents of the file at genpath
+func checkGolden(t *testing.T, srcpath, genpath string) {
+	t.Helper()
+	af, err := astx.ParseFile(srcpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the header names the source file relative to the generated file:
+	af.Path = filepath.Base(srcpath)
+	gen, err := NewFile(af).Gen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := os.ReadFile(genpath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(gen, want) {
+		t.Fatalf("%s is out of date; regenerate it with pgx-gen %s", genpath, srcpath)
+	}
+}
+
+func TestGenExample(t *testing.T) {
+	checkGolden(t, filepath.Join("example", "example.go"), filepath.Join("example", "example_pgxgen.go"))
+}
+
+func TestGenInsertMethods(t *testing.T) {
+	f := genTestFile(t, "package p\n\ntype Row struct {\n\tA int32 `pgx:\"name:a;type:int4\"`\n}\n")
+	if findMethod(f, "RowTableType", "InsertSQL") == nil {
+		t.Error("RowTableType.InsertSQL was not generated")
+	}
+	if findMethod(f, "Row", "InsertArgs") == nil {
+		t.Error("Row.InsertArgs was not generated")
+	}
+}
@@ -0,0 +1,90 @@
+package pgxgen
+
+import (
+	"fmt"
+)
+
+// names of the columns of s which are upserted by default
+func upsertColumnNames(s *Struct) []string {
+	names := []string{}
+	for _, c := range s.Columns {
+		if c.Merge() != MergeSkip {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+const upsertMethodsFmt = `
+%s
+func (t *%sTableType) UpsertSQL(colnames ...string) (string, error) {
+	if len(colnames) == 0 {
+		colnames = %s
+	}
+	sql, err := t.InsertSQL(colnames...)
+	if err != nil {
+		return "", err
+	}
+	indexes, err := t.Indexes(colnames...)
+	if err != nil {
+		return "", err
+	}
+	sets := []string{}
+	for _, index := range indexes {
+		colname := t.Names[index]
+		ident := pgtypes.QuoteIdentifier(colname)
+		isTarget := false
+		for _, target := range t.ConflictTarget {
+			if target == colname {
+				isTarget = true
+				break
+			}
+		}
+		if isTarget {
+			continue
+		}
+		switch t.Merges[index] {
+		case "keep", "skip":
+		case "coalesce":
+			sets = append(sets, ident+" = COALESCE(EXCLUDED."+ident+", "+pgtypes.QuoteTableName(t.TableName)+"."+ident+")")
+		default:
+			sets = append(sets, ident+" = EXCLUDED."+ident)
+		}
+	}
+	sql += " ON CONFLICT (" + strings.Join(pgtypes.QuoteIdentifiers(t.ConflictTarget), ", ") + ")"
+	if len(sets) == 0 {
+		return sql + " DO NOTHING", nil
+	}
+	return sql + " DO UPDATE SET " + strings.Join(sets, ", "), nil
+}
+
+%s
+func (t *%sTableType) Upsert(v *%s, colnames ...string) (*pgtypes.Statement, error) {
+	if len(colnames) == 0 {
+		colnames = %s
+	}
+	sql, err := t.UpsertSQL(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	fe, err := t.Encoders(colnames...)
+	if err != nil {
+		return nil, err
+	}
+	return fe.Statement(sql, v)
+}
+
+`
+
+// generate method defs for ({struct-name})TableType.UpsertSQL and
+// ({struct-name})TableType.Upsert
+func genUpsertMethods(s *Struct) string {
+	defaults := genStringSlice(upsertColumnNames(s))
+	sqlDoc := AutoCommentf("UpsertSQL returns an INSERT ... ON CONFLICT statement for the columns in %sTable named by colnames, with parameter placeholders in the same order as colnames.\n", s.Name)
+	sqlDoc += "//\n"
+	sqlDoc += AutoComment("Conflicts are detected on the ConflictTarget columns. Other columns are updated according to their merge rules (see Merges); if no columns are to be updated, conflicting rows are left as they are. If no column names are provided, all columns without the skip merge rule will be upserted.")
+	doc := AutoCommentLn("Upsert returns an INSERT ... ON CONFLICT statement for the columns named by colnames, along with parameter oids, format codes and encoders bound to v.")
+	doc += "//\n"
+	doc += AutoComment("If no column names are provided, all columns without the skip merge rule will be upserted.")
+	return fmt.Sprintf(upsertMethodsFmt, sqlDoc, s.Name, defaults, doc, s.Name, s.Name, defaults)
+}
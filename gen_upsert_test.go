@@ -0,0 +1,14 @@
+package pgxgen
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUpsertColumnNames(t *testing.T) {
+	f := parseTestFile(t, "test.go", "package p\n\ntype Row struct {\n\tA int32 `pgx:\"name:a;type:int4;pk\"`\n\tB int32 `pgx:\"name:b;type:int4;merge:keep\"`\n\tC int32 `pgx:\"name:c;type:int4;merge:skip\"`\n\tD int32 `pgx:\"name:d;type:int4;merge:coalesce\"`\n}\n")
+	// keep columns are inserted by default, skip columns are not:
+	if names, want := upsertColumnNames(&f.Structs[0]), []string{"a", "b", "d"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("upsertColumnNames = %v; want %v", names, want)
+	}
+}
@@ -0,0 +1,46 @@
+package pgxgen
+
+// MultiDimArrayTypes contains the element data types of array columns which may
+// have more than one dimension (e.g. int4[][]), along with the Go element type
+// of their fields. Fields of multi-dimensional columns are nested slices with a
+// level for each dimension (e.g. [][]int32).
+var MultiDimArrayTypes = map[string]string{
+	"bool":        "bool",
+	"int2":        "int16",
+	"int4":        "int32",
+	"int8":        "int64",
+	"real":        "float32",
+	"float":       "float64",
+	"text":        "string",
+	"varchar":     "string",
+	"timestamp":   "time.Time",
+	"timestampTz": "time.Time",
+	"date":        "time.Time",
+}
+
+// MaxArrayDims is the maximum number of dimensions of an array in PostgreSQL.
+const MaxArrayDims = 6
+
+// add data type names and ops for the multi-dimensional array types, from 2 up
+// to MaxArrayDims dimensions
+func init() {
+	for elem, gotype := range MultiDimArrayTypes {
+		dtName := DataTypeNames[elem+"[]"]
+		coltype, ftype := elem+"[]", "[]"+gotype
+		for dims := 2; dims <= MaxArrayDims; dims++ {
+			coltype, ftype = coltype+"[]", "[]"+ftype
+			DataTypeNames[coltype] = dtName
+			for _, t := range []string{ftype, "*" + ftype} {
+				if Encoders[t] == nil {
+					Encoders[t] = OpMap{}
+				}
+			}
+			Encoders[ftype][coltype] = OpPass | OpMultiDimEncode
+			Encoders["*"+ftype][coltype] = OpDerefPass | OpMultiDimEncode
+			Decoders[coltype] = OpMap{
+				ftype:       OpMultiDimDecode,
+				"*" + ftype: OpPtrAssign | OpMultiDimDecode,
+			}
+		}
+	}
+}
@@ -6,12 +6,12 @@ import (
 )
 
 const (
-	JSONOid = 114
-	// json[] data types are not currently supported
-	JSONArrayOid = 199
-	UUIDOid      = 2950
-	// uuid[] data types are not currently supported
-	UUIDArrayOid = 2951
+	JSONOid       = 114
+	JSONBOid      = 3802
+	JSONArrayOid  = 199
+	JSONBArrayOid = 3807
+	UUIDOid       = 2950
+	UUIDArrayOid  = 2951
 	// xml data types are not currently supported
 	XMLOid = 142
 )
@@ -23,12 +23,18 @@ var DataTypeNames = map[string]string{
 	"int8":          "Int8",
 	"float4":        "Float4",
 	"float8":        "Float8",
+	"real":          "Float4",
+	"float":         "Float8",
 	"bytea":         "Bytea",
 	"text":          "Text",
 	"varchar":       "Varchar",
 	"date":          "Date",
 	"timestampTz":   "TimestampTz",
 	"timestamp":     "Timestamp",
+	"time":          "Time",
+	"timetz":        "TimeTz",
+	"time[]":        "TimeArray",
+	"timetz[]":      "TimeTzArray",
 	"bool[]":        "BoolArray",
 	"int2[]":        "Int2Array",
 	"int4[]":        "Int4Array",
@@ -39,10 +45,28 @@ var DataTypeNames = map[string]string{
 	"varchar[]":     "VarcharArray",
 	"timestamp[]":   "TimestampArray",
 	"timestampTz[]": "TimestampTzArray",
+	"date[]":        "DateArray",
+	"bytea[]":       "ByteaArray",
+	"uuid[]":        "UUIDArray",
+	"json[]":        "JSONArray",
+	"jsonb[]":       "JSONBArray",
+	"numeric[]":     "NumericArray",
+	"interval":      "Interval",
+	"interval[]":    "IntervalArray",
+	"inet":          "Inet",
+	"inet[]":        "InetArray",
+	"cidr":          "Cidr",
+	"cidr[]":        "CidrArray",
+	"macaddr":       "Macaddr",
+	"macaddr[]":     "MacaddrArray",
+	"macaddr8":      "Macaddr8",
+	"macaddr8[]":    "Macaddr8Array",
 	"hstore":        "Hstore",
 	"json":          "JSON",
+	"jsonb":         "JSONB",
 	"uuid":          "UUID",
 	"oid":           "Oid",
+	"numeric":       "Numeric",
 }
 
 var BinaryDataTypes = map[string]bool{
@@ -53,6 +77,7 @@ var BinaryDataTypes = map[string]bool{
 	"Int8":             true,
 	"Float4":           true,
 	"Float8":           true,
+	"Date":             true,
 	"TimestampTz":      true,
 	"TimestampTzArray": true,
 	"Timestamp":        true,
@@ -68,6 +93,44 @@ var BinaryDataTypes = map[string]bool{
 	"Oid":              true,
 	"Hstore":           true,
 	"UUID":             true,
+	"Numeric":          true,
+	"JSONB":            true,
+	"DateArray":        true,
+	"ByteaArray":       true,
+	"UUIDArray":        true,
+	"JSONArray":        true,
+	"JSONBArray":       true,
+	"NumericArray":     true,
+	"Interval":         true,
+	"Time":             true,
+	"TimeTz":           true,
+	"TimeArray":        true,
+	"TimeTzArray":      true,
+	"IntervalArray":    true,
+	"Inet":             true,
+	"InetArray":        true,
+	"Cidr":             true,
+	"CidrArray":        true,
+	"Macaddr":          true,
+	"MacaddrArray":     true,
+	"Macaddr8":         true,
+	"Macaddr8Array":    true,
+}
+
+// JSONDataTypes contains data types which are encoded from and decoded into Go
+// values as JSON (see pgtypes.JSONEncoder and pgtypes.JSONBEncoder)
+var JSONDataTypes = map[string]bool{
+	"json":    true,
+	"jsonb":   true,
+	"json[]":  true,
+	"jsonb[]": true,
+}
+
+// NonComparableDataTypes contains data types which have no equality operator,
+// and cannot be compared within a WHERE clause
+var NonComparableDataTypes = map[string]bool{
+	"json":   true,
+	"json[]": true,
 }
 
 func NormalizeDataType(dataType string) string {
@@ -75,7 +138,9 @@ func NormalizeDataType(dataType string) string {
 		return ""
 	}
 	switch dataType {
-	case "custom", "bytea", "text", "date", "text[]", "varchar[]", "timestampTz[]", "hstore", "json", "uuid", "oid":
+	case "custom", "bytea", "text", "date", "text[]", "varchar[]", "timestampTz[]", "hstore", "json", "jsonb", "uuid", "oid",
+		"date[]", "bytea[]", "uuid[]", "json[]", "jsonb[]", "interval", "interval[]",
+		"inet", "inet[]", "cidr", "cidr[]", "macaddr", "macaddr[]", "macaddr8", "macaddr8[]":
 		return dataType
 	case "bool", "boolean":
 		return "bool"
@@ -91,12 +156,20 @@ func NormalizeDataType(dataType string) string {
 		return "float"
 	case "varchar", "character varying":
 		return "varchar"
-	case "timestamp", "timestamp without time zone", "time", "time without time zone":
+	case "timestamp", "timestamp without time zone":
 		return "timestamp"
-	case "timestampTz", "timestamp with time zone", "time with time zone":
+	case "timestampTz", "timestamp with time zone":
 		return "timestampTz"
+	case "time", "time without time zone":
+		return "time"
+	case "timetz", "time with time zone":
+		return "timetz"
+	case "time[]", "time without time zone[]":
+		return "time[]"
+	case "timetz[]", "time with time zone[]":
+		return "timetz[]"
 	case "bool[]", "boolean[]":
-		return "boolean[]"
+		return "bool[]"
 	case "int2[]", "smallint[]":
 		return "int2[]"
 	case "int4[]", "int[]", "integer[]":
@@ -107,9 +180,24 @@ func NormalizeDataType(dataType string) string {
 		return "real[]"
 	case "float8[]", "float(53)[]", "float[]", "double[]":
 		return "float[]"
-	case "timestamp[]", "time[]":
+	case "timestamp[]":
 		return "timestamp[]"
+	case "numeric", "decimal":
+		return "numeric"
+	case "numeric[]", "decimal[]":
+		return "numeric[]"
 	default:
+		// multi-dimensional arrays are normalized by their element type:
+		if strings.HasSuffix(dataType, "[][]") {
+			if elem := NormalizeDataType(strings.TrimSuffix(dataType, "[]")); elem != "" {
+				return elem + "[]"
+			}
+			return ""
+		}
+		// intervals may declare their fields (e.g. interval day to second):
+		if strings.HasPrefix(dataType, "interval ") {
+			return "interval"
+		}
 		if strings.HasPrefix(dataType, "varchar") || strings.HasPrefix(dataType, "character varying") {
 			return "varchar"
 		}
@@ -120,6 +208,41 @@ func NormalizeDataType(dataType string) string {
 			}
 			return "float"
 		}
+		if _, _, ok := NumericTypmod(dataType); ok {
+			return "numeric"
+		}
+		if _, _, ok := NumericTypmod(strings.TrimSuffix(dataType, "[]")); ok && strings.HasSuffix(dataType, "[]") {
+			return "numeric[]"
+		}
 	}
 	return ""
 }
+
+// NumericTypmod parses the precision and scale of a numeric(p,s) or
+// decimal(p,s) data type. The scale of numeric(p) is 0. If dataType is not a
+// numeric data type with a valid precision, ok will be false.
+func NumericTypmod(dataType string) (precision, scale int, ok bool) {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	open := strings.IndexByte(dataType, '(')
+	if open < 0 || !strings.HasSuffix(dataType, ")") {
+		return 0, 0, false
+	}
+	if name := strings.TrimSpace(dataType[:open]); name != "numeric" && name != "decimal" {
+		return 0, 0, false
+	}
+	args := strings.Split(dataType[open+1:len(dataType)-1], ",")
+	if len(args) > 2 {
+		return 0, 0, false
+	}
+	p, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil || p < 1 || p > 1000 {
+		return 0, 0, false
+	}
+	s := 0
+	if len(args) == 2 {
+		if s, err = strconv.Atoi(strings.TrimSpace(args[1])); err != nil || s < 0 || s > p {
+			return 0, 0, false
+		}
+	}
+	return p, s, true
+}
@@ -19,6 +19,20 @@ const (
 	OpUuidDecode
 	OpUuidStringEncode
 	OpUuidStringDecode
+	OpNumericEncode
+	OpNumericDecode
+	OpNullElemEncode
+	OpNullElemDecode
+	OpMultiDimEncode
+	OpMultiDimDecode
+	OpDurationEncode
+	OpDurationDecode
+	OpTimeOfDayEncode
+	OpTimeOfDayDecode
+	OpInfinityEncode
+	OpInfinityDecode
+	OpNetEncode
+	OpNetDecode
 )
 
 // The high 8 bits of an op are reserved for the Op's cast type (if any).
@@ -101,6 +115,62 @@ func (op Op) UuidStringDecode() bool {
 	return op&OpUuidStringDecode != 0
 }
 
+func (op Op) NumericEncode() bool {
+	return op&OpNumericEncode != 0
+}
+
+func (op Op) NumericDecode() bool {
+	return op&OpNumericDecode != 0
+}
+
+func (op Op) NullElemEncode() bool {
+	return op&OpNullElemEncode != 0
+}
+
+func (op Op) NullElemDecode() bool {
+	return op&OpNullElemDecode != 0
+}
+
+func (op Op) MultiDimEncode() bool {
+	return op&OpMultiDimEncode != 0
+}
+
+func (op Op) MultiDimDecode() bool {
+	return op&OpMultiDimDecode != 0
+}
+
+func (op Op) DurationEncode() bool {
+	return op&OpDurationEncode != 0
+}
+
+func (op Op) DurationDecode() bool {
+	return op&OpDurationDecode != 0
+}
+
+func (op Op) TimeOfDayEncode() bool {
+	return op&OpTimeOfDayEncode != 0
+}
+
+func (op Op) TimeOfDayDecode() bool {
+	return op&OpTimeOfDayDecode != 0
+}
+
+func (op Op) InfinityEncode() bool {
+	return op&OpInfinityEncode != 0
+}
+
+func (op Op) InfinityDecode() bool {
+	return op&OpInfinityDecode != 0
+}
+
+func (op Op) NetEncode() bool {
+	return op&OpNetEncode != 0
+}
+
+func (op Op) NetDecode() bool {
+	return op&OpNetDecode != 0
+}
+
 func (op Op) FormatCast() string {
 	switch op.MaskCast() {
 	case OpCastString:
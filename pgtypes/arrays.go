@@ -0,0 +1,563 @@
+package pgtypes
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/netip"
+	"reflect"
+	"time"
+
+	"github.com/satori/go.uuid"
+	"github.com/wdamron/pgx"
+)
+
+// encode a one-dimensional array of n elements with the given element oid,
+// where enc writes the length-prefixed element at index i
+func encodeArray(w ValueWriter, oid pgx.Oid, n int, enc func(w ValueWriter, i int) error) error {
+	return encodeArrayNulls(w, oid, n, nil, enc)
+}
+
+// encode a one-dimensional array of n elements with the given element oid,
+// where the element at index i is NULL if null (when non-nil) returns true, or
+// is otherwise written by enc
+func encodeArrayNulls(w ValueWriter, oid pgx.Oid, n int, null func(i int) bool, enc func(w ValueWriter, i int) error) error {
+	var elems copyBuf
+	hasNulls := false
+	for i := 0; i < n; i++ {
+		if null != nil && null(i) {
+			elems.WriteInt32(-1)
+			hasNulls = true
+			continue
+		}
+		if err := enc(&elems, i); err != nil {
+			return err
+		}
+	}
+	header := encodeArrayHeaderBytes(oid, n, 0)
+	binary.BigEndian.PutUint32(header[:4], uint32(20+len(elems)))
+	if hasNulls {
+		binary.BigEndian.PutUint32(header[8:12], 1)
+	}
+	w.WriteBytes(header)
+	w.WriteBytes(elems)
+	return nil
+}
+
+// decode the header of a one-dimensional array with the given oid, returning
+// the number of elements
+func decodeArrayHeader(vr ValueReader, oid pgx.Oid, into string) int {
+	if vr.Type().DataType != oid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into %s", vr.Type().DataType, into)))
+		return 0
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return 0
+	}
+
+	numElems, err := decode1dArrayHeader(vr)
+	if err != nil {
+		vr.Fatal(err)
+		return 0
+	}
+	return int(numElems)
+}
+
+// decode the length prefix of an array element, which must not be null
+func decodeArrayElementSize(vr ValueReader) int32 {
+	elSize := vr.ReadInt32()
+	if elSize == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null element"))
+	}
+	return elSize
+}
+
+type uuidArrayEncoderString struct {
+	v []string
+}
+
+func UUIDArrayEncoderString(v []string) pgx.Encoder {
+	return &uuidArrayEncoderString{v}
+}
+
+func (e *uuidArrayEncoderString) FormatCode() int16 { return 1 }
+
+func (e *uuidArrayEncoderString) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *uuidArrayEncoderString) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != UUIDArrayOid {
+		return fmt.Errorf("UUIDArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	return encodeArray(w, UUIDOid, len(e.v), func(w ValueWriter, i int) error {
+		u, err := uuid.FromString(e.v[i])
+		if err != nil {
+			return err
+		}
+		return encodeUUID(w, u)
+	})
+}
+
+type uuidArrayScannerString struct {
+	v *[]string
+}
+
+func UUIDArrayScannerString(v *[]string) pgx.Scanner {
+	return uuidArrayScannerString{v}
+}
+
+func (s uuidArrayScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s uuidArrayScannerString) ScanValue(vr ValueReader) error {
+	us := decodeUUIDArray(vr)
+	if vr.Err() != nil || us == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]string, len(us))
+	for i, u := range us {
+		a[i] = u.String()
+	}
+	*s.v = a
+	return nil
+}
+
+type dateArrayEncoder struct {
+	v []time.Time
+}
+
+func DateArrayEncoder(v []time.Time) pgx.Encoder {
+	return &dateArrayEncoder{v}
+}
+
+func (e *dateArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *dateArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *dateArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != DateArrayOid {
+		return fmt.Errorf("DateArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	return encodeDateArray(w, e.v)
+}
+
+func encodeDateArray(w ValueWriter, vs []time.Time) error {
+	w.WriteBytes(encodeArrayHeaderBytes(DateOid, len(vs), 8))
+	for _, v := range vs {
+		if err := encodeDate(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type dateArrayScanner struct {
+	v *[]time.Time
+}
+
+func DateArrayScanner(v *[]time.Time) pgx.Scanner {
+	return dateArrayScanner{v}
+}
+
+func (s dateArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s dateArrayScanner) ScanValue(vr ValueReader) error {
+	*s.v = decodeDateArray(vr)
+	return vr.Err()
+}
+
+func decodeDateArray(vr ValueReader) []time.Time {
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	numElems := decodeArrayHeader(vr, DateArrayOid, "[]time.Time")
+	if vr.Err() != nil {
+		return nil
+	}
+
+	a := make([]time.Time, numElems)
+	for i := 0; i < len(a); i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil {
+			return nil
+		}
+		if elSize != 4 {
+			vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a date element: %d", elSize)))
+			return nil
+		}
+		a[i] = decodeDateBinary(vr)
+	}
+
+	return a
+}
+
+// decode a binary date as midnight in the location set by SetLocation,
+// following its length prefix
+func decodeDateBinary(vr ValueReader) time.Time {
+	return dateOf(vr.ReadInt32(), defaultLocation()).SentinelTime()
+}
+
+type byteaArrayEncoder struct {
+	v [][]byte
+}
+
+func ByteaArrayEncoder(v [][]byte) pgx.Encoder {
+	return &byteaArrayEncoder{v}
+}
+
+func (e *byteaArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *byteaArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *byteaArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != ByteaArrayOid {
+		return fmt.Errorf("ByteaArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	return encodeArray(w, ByteaOid, len(e.v), func(w ValueWriter, i int) error {
+		return encodeBytea(w, e.v[i])
+	})
+}
+
+type byteaArrayScanner struct {
+	v *[][]byte
+}
+
+func ByteaArrayScanner(v *[][]byte) pgx.Scanner {
+	return byteaArrayScanner{v}
+}
+
+func (s byteaArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s byteaArrayScanner) ScanValue(vr ValueReader) error {
+	*s.v = decodeByteaArray(vr)
+	return vr.Err()
+}
+
+func decodeByteaArray(vr ValueReader) [][]byte {
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	numElems := decodeArrayHeader(vr, ByteaArrayOid, "[][]byte")
+	if vr.Err() != nil {
+		return nil
+	}
+
+	a := make([][]byte, numElems)
+	for i := 0; i < len(a); i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil {
+			return nil
+		}
+		a[i] = vr.ReadBytes(elSize)
+	}
+
+	return a
+}
+
+// jsonArrayEncoder encodes json[] and jsonb[] arrays. The elements of jsonb[]
+// arrays are prefixed with the jsonb format version.
+type jsonArrayEncoder struct {
+	// a slice of values to marshal, or a []string or []json.RawMessage of
+	// encoded elements if raw is true
+	v     interface{}
+	codec string
+	jsonb bool
+	raw   bool
+}
+
+// JSONArrayEncoder returns an encoder which marshals each element of the slice
+// v as an element of a json[] array.
+func JSONArrayEncoder(v interface{}) pgx.Encoder {
+	return &jsonArrayEncoder{v: v}
+}
+
+// JSONArrayEncoderCodec returns an encoder which marshals each element of the
+// slice v as an element of a json[] array, with the JSON codec registered
+// under codec (see RegisterJSONCodec).
+func JSONArrayEncoderCodec(v interface{}, codec string) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, codec: codec}
+}
+
+// JSONArrayEncoderString returns an encoder which encodes each element of v,
+// holding encoded JSON, as an element of a json[] array.
+func JSONArrayEncoderString(v []string) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, raw: true}
+}
+
+// JSONArrayEncoderRaw returns an encoder which encodes each element of v as an
+// element of a json[] array.
+func JSONArrayEncoderRaw(v []json.RawMessage) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, raw: true}
+}
+
+// JSONBArrayEncoder returns an encoder which marshals each element of the
+// slice v as an element of a jsonb[] array.
+func JSONBArrayEncoder(v interface{}) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, jsonb: true}
+}
+
+// JSONBArrayEncoderCodec returns an encoder which marshals each element of the
+// slice v as an element of a jsonb[] array, with the JSON codec registered
+// under codec (see RegisterJSONCodec).
+func JSONBArrayEncoderCodec(v interface{}, codec string) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, codec: codec, jsonb: true}
+}
+
+// JSONBArrayEncoderString returns an encoder which encodes each element of v,
+// holding encoded JSON, as an element of a jsonb[] array.
+func JSONBArrayEncoderString(v []string) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, raw: true, jsonb: true}
+}
+
+// JSONBArrayEncoderRaw returns an encoder which encodes each element of v as an
+// element of a jsonb[] array.
+func JSONBArrayEncoderRaw(v []json.RawMessage) pgx.Encoder {
+	return &jsonArrayEncoder{v: v, raw: true, jsonb: true}
+}
+
+func (e *jsonArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *jsonArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	var arrayOid, elemOid pgx.Oid = JSONArrayOid, JSONOid
+	name := "JSONArrayEncoder"
+	if e.jsonb {
+		arrayOid, elemOid, name = JSONBArrayOid, JSONBOid, "JSONBArrayEncoder"
+	}
+	if oid != arrayOid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", name, oid)
+	}
+
+	encodeElem := func(w ValueWriter, b []byte) error {
+		if e.jsonb {
+			return encodeJSONB(w, b)
+		}
+		w.WriteInt32(int32(len(b)))
+		w.WriteBytes(b)
+		return nil
+	}
+	switch vs := e.v.(type) {
+	case []string:
+		if e.raw {
+			return encodeArray(w, elemOid, len(vs), func(w ValueWriter, i int) error {
+				return encodeElem(w, []byte(vs[i]))
+			})
+		}
+	case []json.RawMessage:
+		if e.raw {
+			return encodeArray(w, elemOid, len(vs), func(w ValueWriter, i int) error {
+				return encodeElem(w, vs[i])
+			})
+		}
+	}
+	rv := reflect.ValueOf(e.v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("%s.Encode cannot encode %T as an array", name, e.v)
+	}
+	return encodeArray(w, elemOid, rv.Len(), func(w ValueWriter, i int) error {
+		b, err := marshalJSON(e.codec, rv.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+		return encodeElem(w, b)
+	})
+}
+
+// jsonArrayScanner decodes json[] and jsonb[] arrays. The elements of jsonb[]
+// arrays are prefixed with the jsonb format version.
+type jsonArrayScanner struct {
+	// a pointer to a slice of values to unmarshal into, or a *[]string or
+	// *[]json.RawMessage for encoded elements if raw is true
+	v     interface{}
+	codec string
+	jsonb bool
+	raw   bool
+}
+
+// JSONArrayScanner returns a scanner which unmarshals each element of a json[]
+// array into an element of the slice pointed to by v.
+func JSONArrayScanner(v interface{}) pgx.Scanner {
+	return jsonArrayScanner{v: v}
+}
+
+// JSONArrayScannerCodec returns a scanner which unmarshals each element of a
+// json[] array into an element of the slice pointed to by v, with the JSON
+// codec registered under codec (see RegisterJSONCodec).
+func JSONArrayScannerCodec(v interface{}, codec string) pgx.Scanner {
+	return jsonArrayScanner{v: v, codec: codec}
+}
+
+// JSONArrayScannerString returns a scanner which decodes each element of a
+// json[] array as encoded JSON into the slice pointed to by v.
+func JSONArrayScannerString(v *[]string) pgx.Scanner {
+	return jsonArrayScanner{v: v, raw: true}
+}
+
+// JSONArrayScannerRaw returns a scanner which decodes each element of a json[]
+// array as encoded JSON into the slice pointed to by v.
+func JSONArrayScannerRaw(v *[]json.RawMessage) pgx.Scanner {
+	return jsonArrayScanner{v: v, raw: true}
+}
+
+// JSONBArrayScanner returns a scanner which unmarshals each element of a
+// jsonb[] array into an element of the slice pointed to by v.
+func JSONBArrayScanner(v interface{}) pgx.Scanner {
+	return jsonArrayScanner{v: v, jsonb: true}
+}
+
+// JSONBArrayScannerCodec returns a scanner which unmarshals each element of a
+// jsonb[] array into an element of the slice pointed to by v, with the JSON
+// codec registered under codec (see RegisterJSONCodec).
+func JSONBArrayScannerCodec(v interface{}, codec string) pgx.Scanner {
+	return jsonArrayScanner{v: v, codec: codec, jsonb: true}
+}
+
+// JSONBArrayScannerString returns a scanner which decodes each element of a
+// jsonb[] array as encoded JSON into the slice pointed to by v.
+func JSONBArrayScannerString(v *[]string) pgx.Scanner {
+	return jsonArrayScanner{v: v, raw: true, jsonb: true}
+}
+
+// JSONBArrayScannerRaw returns a scanner which decodes each element of a jsonb[]
+// array as encoded JSON into the slice pointed to by v.
+func JSONBArrayScannerRaw(v *[]json.RawMessage) pgx.Scanner {
+	return jsonArrayScanner{v: v, raw: true, jsonb: true}
+}
+
+func (s jsonArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonArrayScanner) ScanValue(vr ValueReader) error {
+	rv := reflect.ValueOf(s.v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		vr.Fatal(fmt.Errorf("Cannot decode a JSON array into %T", s.v))
+		return vr.Err()
+	}
+	slice := rv.Elem()
+	if vr.Len() == -1 {
+		slice.Set(reflect.Zero(slice.Type()))
+		return nil
+	}
+
+	var arrayOid pgx.Oid = JSONArrayOid
+	into := "json[]"
+	if s.jsonb {
+		arrayOid, into = JSONBArrayOid, "jsonb[]"
+	}
+	numElems := decodeArrayHeader(vr, arrayOid, into)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+
+	a := reflect.MakeSlice(slice.Type(), numElems, numElems)
+	for i := 0; i < numElems; i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil {
+			return vr.Err()
+		}
+		if s.jsonb {
+			if elSize < 1 {
+				vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a jsonb element: %d", elSize)))
+				return vr.Err()
+			}
+			if version := vr.ReadUint8(); version != jsonbVersion {
+				vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown jsonb format version: %d", version)))
+				return vr.Err()
+			}
+			elSize--
+		}
+		b := vr.ReadBytes(elSize)
+		if vr.Err() != nil {
+			return vr.Err()
+		}
+		elem := a.Index(i).Addr().Interface()
+		switch raw := elem.(type) {
+		case *string:
+			if s.raw {
+				*raw = string(b)
+				continue
+			}
+		case *json.RawMessage:
+			if s.raw {
+				*raw = b
+				continue
+			}
+		}
+		if err := unmarshalJSON(s.codec, b, elem); err != nil {
+			return err
+		}
+	}
+	slice.Set(a)
+	return nil
+}
+
+// InetArrayEncoderIP returns an encoder which writes v as an inet[] value, with
+// a NULL element for each nil element of v.
+func InetArrayEncoderIP(v []net.IP) pgx.Encoder {
+	return inetArrayEncoderIP("InetArrayEncoder", InetArrayOid, InetOid, v)
+}
+
+// InetArrayEncoderIPNet returns an encoder which writes v as an inet[] value,
+// with a NULL element for each nil element of v.
+func InetArrayEncoderIPNet(v []*net.IPNet) pgx.Encoder {
+	return inetArrayEncoderIPNet("InetArrayEncoder", InetArrayOid, InetOid, v)
+}
+
+// InetArrayEncoderAddr returns an encoder which writes v as an inet[] value,
+// with a NULL element for each invalid (zero) element of v.
+func InetArrayEncoderAddr(v []netip.Addr) pgx.Encoder {
+	return inetArrayEncoderAddr("InetArrayEncoder", InetArrayOid, InetOid, v)
+}
+
+// InetArrayEncoderPrefix returns an encoder which writes v as an inet[] value,
+// with a NULL element for each invalid (zero) element of v.
+func InetArrayEncoderPrefix(v []netip.Prefix) pgx.Encoder {
+	return inetArrayEncoderPrefix("InetArrayEncoder", InetArrayOid, InetOid, v)
+}
+
+// InetArrayScannerIP returns a scanner which decodes the addresses of inet[]
+// values into v, with a nil element for each NULL element.
+func InetArrayScannerIP(v *[]net.IP) pgx.Scanner {
+	return inetArrayScannerIP(InetArrayOid, v)
+}
+
+// InetArrayScannerIPNet returns a scanner which decodes inet[] values into v,
+// with a nil element for each NULL element.
+func InetArrayScannerIPNet(v *[]*net.IPNet) pgx.Scanner {
+	return inetArrayScannerIPNet(InetArrayOid, v)
+}
+
+// InetArrayScannerAddr returns a scanner which decodes the addresses of inet[]
+// values into v, with an invalid (zero) element for each NULL element.
+func InetArrayScannerAddr(v *[]netip.Addr) pgx.Scanner {
+	return inetArrayScannerAddr(InetArrayOid, v)
+}
+
+// InetArrayScannerPrefix returns a scanner which decodes inet[] values into v,
+// with an invalid (zero) element for each NULL element.
+func InetArrayScannerPrefix(v *[]netip.Prefix) pgx.Scanner {
+	return inetArrayScannerPrefix(InetArrayOid, v)
+}
@@ -0,0 +1,285 @@
+package pgtypes
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/wdamron/pgx"
+)
+
+// copySignature begins the header of every binary COPY stream, and is followed
+// by a 32-bit flags field and a 32-bit header extension length.
+const copySignature = "PGCOPY\n\377\r\n\000"
+
+// copyBuf is a ValueWriter which accumulates encoded values in memory.
+type copyBuf []byte
+
+func (b *copyBuf) WriteBytes(p []byte) {
+	*b = append(*b, p...)
+}
+
+func (b *copyBuf) WriteString(s string) {
+	*b = append(*b, s...)
+}
+
+func (b *copyBuf) WriteInt32(n int32) {
+	*b = append(*b, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+}
+
+func (b *copyBuf) writeInt16(n int16) {
+	*b = append(*b, byte(n>>8), byte(n))
+}
+
+// CopyWriter writes rows to w in the PostgreSQL binary COPY format, as read by
+// COPY ... FROM STDIN (FORMAT binary).
+//
+// Fields are written by the encoders within this package (see ValueEncoder), or
+// by the null wrapper types of pgx (pgx.NullInt32, etc.). The header is written
+// along with the first row, and the trailer is written by Close.
+type CopyWriter struct {
+	w           io.Writer
+	buf         copyBuf
+	wroteHeader bool
+}
+
+// NewCopyWriter creates a CopyWriter which writes a binary COPY stream to w.
+func NewCopyWriter(w io.Writer) *CopyWriter {
+	return &CopyWriter{w: w}
+}
+
+func (cw *CopyWriter) writeHeader() {
+	if cw.wroteHeader {
+		return
+	}
+	cw.buf = append(cw.buf, copySignature...)
+	cw.buf.WriteInt32(0) // flags
+	cw.buf.WriteInt32(0) // header extension length
+	cw.wroteHeader = true
+}
+
+// WriteRow writes a single tuple to the COPY stream, encoding each field with
+// the encoder and oid at the same position within encoders and oids.
+func (cw *CopyWriter) WriteRow(oids []pgx.Oid, encoders []pgx.Encoder) error {
+	if len(oids) != len(encoders) {
+		return fmt.Errorf("CopyWriter.WriteRow received %d oids for %d encoders", len(oids), len(encoders))
+	}
+	cw.buf = cw.buf[:0]
+	cw.writeHeader()
+	cw.buf.writeInt16(int16(len(encoders)))
+	for i, e := range encoders {
+		ve, ok := e.(ValueEncoder)
+		if !ok {
+			if ve, ok = nullValueEncoder(e, oids[i]); !ok {
+				return fmt.Errorf("CopyWriter.WriteRow cannot encode %T into the binary COPY format", e)
+			}
+		}
+		if _, null := ve.(*nullEncoder); !null && ve.FormatCode() != BinaryFormatCode && !isBinaryText(oids[i]) {
+			return fmt.Errorf("CopyWriter.WriteRow cannot encode text-format OID %d into the binary COPY format", oids[i])
+		}
+		if err := ve.EncodeValue(&cw.buf, oids[i]); err != nil {
+			return err
+		}
+	}
+	_, err := cw.w.Write(cw.buf)
+	return err
+}
+
+// Close writes the trailer of the COPY stream. Close does not close the
+// underlying io.Writer.
+func (cw *CopyWriter) Close() error {
+	cw.buf = cw.buf[:0]
+	cw.writeHeader()
+	cw.buf.writeInt16(-1)
+	_, err := cw.w.Write(cw.buf)
+	return err
+}
+
+// isBinaryText reports whether the binary format of the data type with the
+// given oid is identical to its text format.
+func isBinaryText(oid pgx.Oid) bool {
+	switch oid {
+	case TextOid, VarcharOid, JSONOid:
+		return true
+	}
+	return false
+}
+
+// CopyReader reads rows from a stream in the PostgreSQL binary COPY format, as
+// written by COPY ... TO STDOUT (FORMAT binary).
+//
+// Call Next to advance to each tuple, then ReadValue once for each field within
+// the tuple. Fields may be decoded by the scanners within this package, or by
+// the null wrapper types of pgx (see ValueScannerOf).
+type CopyReader struct {
+	r      *bufio.Reader
+	header [8]byte
+}
+
+// NewCopyReader creates a CopyReader which reads a binary COPY stream from r,
+// after reading and validating the header of the stream.
+func NewCopyReader(r io.Reader) (*CopyReader, error) {
+	cr := &CopyReader{r: bufio.NewReader(r)}
+	signature := make([]byte, len(copySignature))
+	if _, err := io.ReadFull(cr.r, signature); err != nil {
+		return nil, err
+	}
+	if string(signature) != copySignature {
+		return nil, pgx.ProtocolError("Invalid signature for binary COPY stream")
+	}
+	flags, err := cr.readInt32()
+	if err != nil {
+		return nil, err
+	}
+	if flags&(1<<16) != 0 {
+		return nil, pgx.ProtocolError("Binary COPY streams with OIDs are not supported")
+	}
+	extLen, err := cr.readInt32()
+	if err != nil {
+		return nil, err
+	}
+	if extLen < 0 {
+		return nil, pgx.ProtocolError(fmt.Sprintf("Invalid header extension length for binary COPY stream: %d", extLen))
+	}
+	if _, err = io.CopyN(io.Discard, cr.r, int64(extLen)); err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+func (cr *CopyReader) readInt32() (int32, error) {
+	b := cr.header[:4]
+	if _, err := io.ReadFull(cr.r, b); err != nil {
+		return 0, err
+	}
+	return int32(b[0])<<24 | int32(b[1])<<16 | int32(b[2])<<8 | int32(b[3]), nil
+}
+
+// Next advances to the next tuple within the COPY stream, returning the number
+// of fields within the tuple. After the trailer of the stream has been read,
+// Next returns io.EOF.
+func (cr *CopyReader) Next() (int, error) {
+	b := cr.header[:2]
+	if _, err := io.ReadFull(cr.r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	count := int16(b[0])<<8 | int16(b[1])
+	if count == -1 {
+		return 0, io.EOF
+	}
+	if count < 0 {
+		return 0, pgx.ProtocolError(fmt.Sprintf("Invalid field count for binary COPY tuple: %d", count))
+	}
+	return int(count), nil
+}
+
+// ReadValue reads the next field within the current tuple, returning a
+// ValueReader which decodes the field as a binary value of the type with the
+// given oid.
+func (cr *CopyReader) ReadValue(oid pgx.Oid) (*CopyValue, error) {
+	n, err := cr.readInt32()
+	if err != nil {
+		return nil, err
+	}
+	v := &CopyValue{
+		fd: pgx.FieldDescription{DataType: oid, FormatCode: BinaryFormatCode},
+		n:  n,
+	}
+	if n < -1 {
+		return nil, pgx.ProtocolError(fmt.Sprintf("Invalid field length for binary COPY tuple: %d", n))
+	}
+	if n > 0 {
+		v.b = make([]byte, n)
+		if _, err = io.ReadFull(cr.r, v.b); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// CopyValue is a ValueReader for a single field read from a binary COPY stream
+// (see CopyReader.ReadValue).
+type CopyValue struct {
+	fd  pgx.FieldDescription
+	b   []byte
+	n   int32
+	err error
+}
+
+// Len returns the length of the value in bytes, or -1 if the value is null.
+func (v *CopyValue) Len() int32 { return v.n }
+
+// Type returns a description of the value, with the oid given to
+// CopyReader.ReadValue and the binary format code.
+func (v *CopyValue) Type() *pgx.FieldDescription { return &v.fd }
+
+// Fatal records err, if no error has been recorded yet.
+func (v *CopyValue) Fatal(err error) {
+	if v.err == nil {
+		v.err = err
+	}
+}
+
+// Err returns the first error recorded by Fatal.
+func (v *CopyValue) Err() error { return v.err }
+
+func (v *CopyValue) next(count int32) []byte {
+	if v.err != nil {
+		return nil
+	}
+	if count < 0 || int(count) > len(v.b) {
+		v.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot read %d bytes from a binary COPY field with %d bytes remaining", count, len(v.b))))
+		return nil
+	}
+	b := v.b[:count]
+	v.b = v.b[count:]
+	return b
+}
+
+func (v *CopyValue) ReadUint8() byte {
+	b := v.next(1)
+	if b == nil {
+		return 0
+	}
+	return b[0]
+}
+
+func (v *CopyValue) ReadInt16() int16 {
+	b := v.next(2)
+	if b == nil {
+		return 0
+	}
+	return int16(binary.BigEndian.Uint16(b))
+}
+
+func (v *CopyValue) ReadInt32() int32 {
+	b := v.next(4)
+	if b == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(b))
+}
+
+func (v *CopyValue) ReadInt64() int64 {
+	b := v.next(8)
+	if b == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(b))
+}
+
+func (v *CopyValue) ReadString(count int32) string {
+	return string(v.next(count))
+}
+
+func (v *CopyValue) ReadBytes(count int32) []byte {
+	b := v.next(count)
+	if b == nil {
+		return nil
+	}
+	return append([]byte(nil), b...)
+}
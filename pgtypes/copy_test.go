@@ -0,0 +1,199 @@
+package pgtypes
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+// copyRow writes a single row to a binary COPY stream, then returns a reader
+// positioned at the first field of the row
+func copyRow(t *testing.T, oids []pgx.Oid, encoders []pgx.Encoder) *CopyReader {
+	t.Helper()
+	var b bytes.Buffer
+	cw := NewCopyWriter(&b)
+	if err := cw.WriteRow(oids, encoders); err != nil {
+		t.Fatal(err)
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	cr, err := NewCopyReader(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := cr.Next(); err != nil || n != len(oids) {
+		t.Fatalf("Next() = %d, %v; want %d fields", n, err, len(oids))
+	}
+	return cr
+}
+
+// scanField decodes the next field of the current row into s
+func scanField(t *testing.T, cr *CopyReader, oid pgx.Oid, s pgx.Scanner) error {
+	t.Helper()
+	v, err := cr.ReadValue(oid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, ok := ValueScannerOf(s)
+	if !ok {
+		t.Fatalf("%T is not a ValueScanner", s)
+	}
+	return vs.ScanValue(v)
+}
+
+// binaryValue returns a ValueReader for an encoded value, following its length
+// prefix
+func binaryValue(oid pgx.Oid, encoded []byte) *CopyValue {
+	n := int32(encoded[0])<<24 | int32(encoded[1])<<16 | int32(encoded[2])<<8 | int32(encoded[3])
+	return &CopyValue{
+		fd: pgx.FieldDescription{DataType: oid, FormatCode: BinaryFormatCode},
+		b:  encoded[4:],
+		n:  n,
+	}
+}
+
+func TestCopyWriterHeaderTrailer(t *testing.T) {
+	header := "PGCOPY\n\377\r\n\000" + "\x00\x00\x00\x00" + "\x00\x00\x00\x00"
+	trailer := "\xff\xff"
+
+	var b bytes.Buffer
+	if err := NewCopyWriter(&b).Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != header+trailer {
+		t.Fatalf("empty stream = %q; want %q", got, header+trailer)
+	}
+
+	b.Reset()
+	cw := NewCopyWriter(&b)
+	for i := 0; i < 2; i++ {
+		if err := cw.WriteRow([]pgx.Oid{Int4Oid, TextOid}, []pgx.Encoder{Int4Encoder(7), TextEncoder("hi")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	row := "\x00\x02" + "\x00\x00\x00\x04\x00\x00\x00\x07" + "\x00\x00\x00\x02hi"
+	if got, want := b.String(), header+row+row+trailer; got != want {
+		t.Fatalf("stream = %q; want %q", got, want)
+	}
+}
+
+func TestCopyWriterErrors(t *testing.T) {
+	cw := NewCopyWriter(io.Discard)
+	if err := cw.WriteRow([]pgx.Oid{Int4Oid}, nil); err == nil {
+		t.Error("expected an error for mismatched oids and encoders")
+	}
+	if err := cw.WriteRow([]pgx.Oid{Int4Oid}, []pgx.Encoder{pgx.NullInt32{Int32: 1, Valid: true}}); err != nil {
+		t.Error(err)
+	}
+	if err := cw.WriteRow([]pgx.Oid{JSONBOid}, []pgx.Encoder{JSONEncoder(1)}); err == nil {
+		t.Error("expected an error for a text-format encoder")
+	}
+}
+
+func TestCopyReader(t *testing.T) {
+	var b bytes.Buffer
+	cw := NewCopyWriter(&b)
+	for i := 0; i < 3; i++ {
+		if err := cw.WriteRow([]pgx.Oid{Int4Oid, TextOid}, []pgx.Encoder{Int4Encoder(int32(i)), TextEncoder("row")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cw.Close()
+	stream := append([]byte(nil), b.Bytes()...)
+
+	cr, err := NewCopyReader(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := 0
+	for {
+		n, err := cr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil || n != 2 {
+			t.Fatalf("Next() = %d, %v", n, err)
+		}
+		var i int32
+		var s string
+		if err := scanField(t, cr, Int4Oid, Int4Scanner(&i)); err != nil {
+			t.Fatal(err)
+		}
+		if err := scanField(t, cr, TextOid, TextScanner(&s)); err != nil {
+			t.Fatal(err)
+		}
+		if i != int32(rows) || s != "row" {
+			t.Fatalf("row %d = %d, %q", rows, i, s)
+		}
+		rows++
+	}
+	if rows != 3 {
+		t.Fatalf("read %d rows; want 3", rows)
+	}
+
+	// a stream without its trailer is truncated
+	cr, err = NewCopyReader(bytes.NewReader(stream[:len(stream)-2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		cr.Next()
+		cr.ReadValue(Int4Oid)
+		cr.ReadValue(TextOid)
+	}
+	if _, err := cr.Next(); err != io.ErrUnexpectedEOF {
+		t.Fatalf("Next() = %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+
+	bad := append([]byte(nil), stream...)
+	bad[0] = 'X'
+	if _, err := NewCopyReader(bytes.NewReader(bad)); err == nil {
+		t.Error("expected an error for an invalid signature")
+	}
+	bad = append([]byte(nil), stream...)
+	bad[len(copySignature)+1] = 1 // flags: OIDs included
+	if _, err := NewCopyReader(bytes.NewReader(bad)); err == nil {
+		t.Error("expected an error for a stream with OIDs")
+	}
+}
+
+func TestCopyPgxNullTypes(t *testing.T) {
+	var nilInt32 *pgx.NullInt32
+	oids := []pgx.Oid{Int4Oid, Int4Oid, VarcharOid, Int8Oid, DateOid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		pgx.NullInt32{Int32: 7, Valid: true},
+		nilInt32,
+		&pgx.NullString{String: "x", Valid: true},
+		pgx.NullInt64{},
+		pgx.NullTime{Time: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC), Valid: true},
+	})
+
+	var i32 pgx.NullInt32
+	if err := scanField(t, cr, Int4Oid, &i32); err != nil || i32 != (pgx.NullInt32{Int32: 7, Valid: true}) {
+		t.Fatal(err, i32)
+	}
+	i32 = pgx.NullInt32{Int32: 3, Valid: true}
+	if err := scanField(t, cr, Int4Oid, &i32); err != nil || i32.Valid {
+		t.Fatal(err, i32)
+	}
+	var s pgx.NullString
+	if err := scanField(t, cr, VarcharOid, &s); err != nil || s != (pgx.NullString{String: "x", Valid: true}) {
+		t.Fatal(err, s)
+	}
+	i64 := &pgx.NullInt64{Int64: 3, Valid: true}
+	nullable := NullableScanner(func() { i64 = nil }, func() pgx.Scanner { i64 = new(pgx.NullInt64); return i64 })
+	if err := scanField(t, cr, Int8Oid, nullable); err != nil || i64 != nil {
+		t.Fatal(err, i64)
+	}
+	var tm pgx.NullTime
+	if err := scanField(t, cr, DateOid, &tm); err != nil || !tm.Valid || !tm.Time.Equal(time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Fatal(err, tm)
+	}
+}
@@ -0,0 +1,95 @@
+package pgtypes
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+func testDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDateRoundTrip(t *testing.T) {
+	neg, pos := GetInfinity()
+	tests := []struct {
+		name string
+		date time.Time
+		days int32
+	}{
+		{"epoch", testDate(2000, 1, 1), 0},
+		{"day before epoch", testDate(1999, 12, 31), -1},
+		{"day after epoch", testDate(2000, 1, 2), 1},
+		{"leap day 2000", testDate(2000, 2, 29), 59},
+		{"after leap day 2000", testDate(2000, 3, 1), 60},
+		{"leap day 2024", testDate(2024, 2, 29), 8825},
+		{"end of february 1900", testDate(1900, 2, 28), -36466},
+		{"no leap day 1900", testDate(1900, 3, 1), -36465},
+		{"first day AD", testDate(1, 1, 1), -730119},
+		{"leap day 1 BC", testDate(0, 2, 29), -730426},
+		{"after leap day 1 BC", testDate(0, 3, 1), -730425},
+		{"julian day zero 4714 BC", testDate(-4713, 11, 24), -2451545},
+		{"infinity", pos, math.MaxInt32},
+		{"-infinity", neg, math.MinInt32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf copyBuf
+			if err := encodeDate(&buf, tt.date); err != nil {
+				t.Fatal(err)
+			}
+			var want copyBuf
+			want.WriteInt32(4)
+			want.WriteInt32(tt.days)
+			if string(buf) != string(want) {
+				t.Fatalf("encodeDate(%v) = %x; want %x", tt.date, []byte(buf), []byte(want))
+			}
+			vr := binaryValue(DateOid, buf)
+			got := decodeDateBinary(vr)
+			if err := vr.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.date) {
+				t.Fatalf("decodeDateBinary(%d) = %v; want %v", tt.days, got, tt.date)
+			}
+		})
+	}
+}
+
+func TestDateCalendarDay(t *testing.T) {
+	// dates are encoded for the calendar day in the location of the time,
+	// regardless of the time of day
+	east := time.FixedZone("", 14*3600)
+	west := time.FixedZone("", -12*3600)
+	for _, v := range []time.Time{
+		time.Date(2000, 1, 1, 0, 0, 0, 0, east),
+		time.Date(2000, 1, 1, 23, 59, 59, 999999999, west),
+		time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC),
+	} {
+		var buf copyBuf
+		encodeDate(&buf, v)
+		if got := decodeDateBinary(binaryValue(DateOid, buf)); !got.Equal(testDate(2000, 1, 1)) {
+			t.Errorf("date of %v = %v", v, got)
+		}
+	}
+}
+
+func TestDateArray(t *testing.T) {
+	dates := []time.Time{testDate(2000, 1, 1), testDate(0, 2, 29), testDate(2024, 2, 29)}
+	cr := copyRow(t, []pgx.Oid{DateOid, DateArrayOid}, []pgx.Encoder{DateEncoder(dates[1]), DateArrayEncoder(dates)})
+	var d time.Time
+	if err := scanField(t, cr, DateOid, DateScanner(&d)); err != nil || !d.Equal(dates[1]) {
+		t.Fatal(err, d)
+	}
+	var ds []time.Time
+	if err := scanField(t, cr, DateArrayOid, DateArrayScanner(&ds)); err != nil || len(ds) != len(dates) {
+		t.Fatal(err, ds)
+	}
+	for i := range dates {
+		if !ds[i].Equal(dates[i]) {
+			t.Errorf("element %d = %v; want %v", i, ds[i], dates[i])
+		}
+	}
+}
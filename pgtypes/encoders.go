@@ -2,7 +2,6 @@ package pgtypes
 
 import (
 	"encoding/binary"
-	"encoding/json"
 	"fmt"
 	"math"
 	"time"
@@ -11,15 +10,57 @@ import (
 	"github.com/wdamron/pgx"
 )
 
+// ValueWriter is implemented by *pgx.WriteBuf, and by any other buffer which
+// encoded values may be written to (see CopyWriter).
+type ValueWriter interface {
+	WriteBytes(b []byte)
+	WriteString(s string)
+	WriteInt32(n int32)
+}
+
+// ValueEncoder is implemented by the encoders within this package, which may
+// write encoded values to any ValueWriter.
+type ValueEncoder interface {
+	pgx.Encoder
+	EncodeValue(w ValueWriter, oid pgx.Oid) error
+}
+
 const (
 	len1  = "\x00\x00\x00\x01"
 	len2  = "\x00\x00\x00\x02"
 	len4  = "\x00\x00\x00\x04"
 	len8  = "\x00\x00\x00\x08"
 	len10 = "\x00\x00\x00\x0a"
+	len12 = "\x00\x00\x00\x0c"
 	len16 = "\x00\x00\x00\x10"
 )
 
+type nullEncoder struct {
+	oid    pgx.Oid
+	format int16
+}
+
+// NullEncoder returns an encoder which writes NULL for a parameter with the
+// given oid and format code.
+func NullEncoder(oid pgx.Oid, format int16) pgx.Encoder {
+	return &nullEncoder{oid, format}
+}
+
+func (e *nullEncoder) FormatCode() int16 { return e.format }
+
+func (e *nullEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *nullEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.oid {
+		return fmt.Errorf("NullEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	w.WriteInt32(-1)
+	return nil
+}
+
 type boolEncoder struct {
 	v bool
 }
@@ -31,19 +72,23 @@ func BoolEncoder(v bool) pgx.Encoder {
 func (e *boolEncoder) FormatCode() int16 { return 1 }
 
 func (e *boolEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *boolEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != BoolOid {
 		return fmt.Errorf("BoolEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeBool(wbuf, e.v)
+	return encodeBool(w, e.v)
 }
 
-func encodeBool(wbuf *pgx.WriteBuf, v bool) error {
+func encodeBool(w ValueWriter, v bool) error {
 	var cast byte
 	if v {
 		cast = 1
 	}
-	wbuf.WriteBytes(append([]byte(len1), cast))
+	w.WriteBytes(append([]byte(len1), cast))
 	return nil
 }
 
@@ -58,15 +103,19 @@ func Int2Encoder(v int16) pgx.Encoder {
 func (e *int2Encoder) FormatCode() int16 { return 1 }
 
 func (e *int2Encoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int2Encoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int2Oid {
 		return fmt.Errorf("Int2Encoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt2(wbuf, e.v)
+	return encodeInt2(w, e.v)
 }
 
-func encodeInt2(wbuf *pgx.WriteBuf, v int16) error {
-	wbuf.WriteBytes(append([]byte(len2), byte(v>>8), byte(v)))
+func encodeInt2(w ValueWriter, v int16) error {
+	w.WriteBytes(append([]byte(len2), byte(v>>8), byte(v)))
 	return nil
 }
 
@@ -81,15 +130,19 @@ func Int4Encoder(v int32) pgx.Encoder {
 func (e *int4Encoder) FormatCode() int16 { return 1 }
 
 func (e *int4Encoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int4Encoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int4Oid {
 		return fmt.Errorf("Int4Encoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt4(wbuf, e.v)
+	return encodeInt4(w, e.v)
 }
 
-func encodeInt4(wbuf *pgx.WriteBuf, v int32) error {
-	wbuf.WriteBytes(append([]byte(len4), byte(v>>24), byte(v>>16), byte(v>>8), byte(v)))
+func encodeInt4(w ValueWriter, v int32) error {
+	w.WriteBytes(append([]byte(len4), byte(v>>24), byte(v>>16), byte(v>>8), byte(v)))
 	return nil
 }
 
@@ -104,18 +157,22 @@ func Int8Encoder(v int64) pgx.Encoder {
 func (e *int8Encoder) FormatCode() int16 { return 1 }
 
 func (e *int8Encoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int8Encoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int8Oid {
 		return fmt.Errorf("Int8Encoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt8(wbuf, e.v)
+	return encodeInt8(w, e.v)
 }
 
-func encodeInt8(wbuf *pgx.WriteBuf, v int64) error {
+func encodeInt8(w ValueWriter, v int64) error {
 	b := []byte(len8)
 	b = append(b, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32))
 	b = append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
-	wbuf.WriteBytes(b)
+	w.WriteBytes(b)
 	return nil
 }
 
@@ -130,15 +187,19 @@ func Float4Encoder(v float32) pgx.Encoder {
 func (e *float4Encoder) FormatCode() int16 { return 1 }
 
 func (e *float4Encoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *float4Encoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Float4Oid {
 		return fmt.Errorf("Float4Encoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeFloat4(wbuf, e.v)
+	return encodeFloat4(w, e.v)
 }
 
-func encodeFloat4(wbuf *pgx.WriteBuf, v float32) error {
-	return encodeInt4(wbuf, int32(math.Float32bits(v)))
+func encodeFloat4(w ValueWriter, v float32) error {
+	return encodeInt4(w, int32(math.Float32bits(v)))
 }
 
 type float8Encoder struct {
@@ -152,15 +213,19 @@ func Float8Encoder(v float64) pgx.Encoder {
 func (e *float8Encoder) FormatCode() int16 { return 1 }
 
 func (e *float8Encoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *float8Encoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Float8Oid {
 		return fmt.Errorf("Float8Encoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeFloat8(wbuf, e.v)
+	return encodeFloat8(w, e.v)
 }
 
-func encodeFloat8(wbuf *pgx.WriteBuf, v float64) error {
-	return encodeInt8(wbuf, int64(math.Float64bits(v)))
+func encodeFloat8(w ValueWriter, v float64) error {
+	return encodeInt8(w, int64(math.Float64bits(v)))
 }
 
 type byteaEncoder struct {
@@ -174,21 +239,25 @@ func ByteaEncoder(v []byte) pgx.Encoder {
 func (e *byteaEncoder) FormatCode() int16 { return 1 }
 
 func (e *byteaEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *byteaEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != ByteaOid {
 		return fmt.Errorf("ByteaEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeBytea(wbuf, e.v)
+	return encodeBytea(w, e.v)
 }
 
-func encodeBytea(wbuf *pgx.WriteBuf, v []byte) error {
+func encodeBytea(w ValueWriter, v []byte) error {
 	totalLen := 4 + len(v)
 	b := make([]byte, totalLen)
 	binary.BigEndian.PutUint32(b, uint32(len(v)))
 	if len(v) != 0 {
 		copy(b[4:totalLen], v)
 	}
-	wbuf.WriteBytes(b)
+	w.WriteBytes(b)
 	return nil
 }
 
@@ -203,18 +272,21 @@ func TextEncoder(v string) pgx.Encoder {
 func (e *textEncoder) FormatCode() int16 { return 0 }
 
 func (e *textEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
-	return encodeText(wbuf, e.v)
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *textEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	return encodeText(w, e.v)
 }
 
-func encodeText(wbuf *pgx.WriteBuf, v string) error {
+func encodeText(w ValueWriter, v string) error {
 	totalLen := 4 + len(v)
 	b := make([]byte, totalLen)
 	binary.BigEndian.PutUint32(b, uint32(len(v)))
 	if len(v) != 0 {
 		copy(b[4:totalLen], v)
 	}
-	wbuf.WriteBytes(b)
-	wbuf.WriteString(v)
+	w.WriteBytes(b)
 	return nil
 }
 
@@ -229,17 +301,21 @@ func TextEncoderBytes(v []byte) pgx.Encoder {
 func (e *textEncoderBytes) FormatCode() int16 { return 0 }
 
 func (e *textEncoderBytes) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
-	return encodeTextBytes(wbuf, e.v)
+	return e.EncodeValue(wbuf, oid)
 }
 
-func encodeTextBytes(wbuf *pgx.WriteBuf, v []byte) error {
+func (e *textEncoderBytes) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	return encodeTextBytes(w, e.v)
+}
+
+func encodeTextBytes(w ValueWriter, v []byte) error {
 	totalLen := 4 + len(v)
 	b := make([]byte, totalLen)
 	binary.BigEndian.PutUint32(b, uint32(len(v)))
 	if len(v) != 0 {
 		copy(b[4:totalLen], v)
 	}
-	wbuf.WriteBytes(append(b, v...))
+	w.WriteBytes(b)
 	return nil
 }
 
@@ -254,15 +330,19 @@ func VarcharEncoder(v string) pgx.Encoder {
 func (e *varcharEncoder) FormatCode() int16 { return 0 }
 
 func (e *varcharEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *varcharEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != VarcharOid {
 		return fmt.Errorf("VarcharEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeVarchar(wbuf, e.v)
+	return encodeVarchar(w, e.v)
 }
 
-func encodeVarchar(wbuf *pgx.WriteBuf, v string) error {
-	return encodeText(wbuf, v)
+func encodeVarchar(w ValueWriter, v string) error {
+	return encodeText(w, v)
 }
 
 type varcharEncoderBytes struct {
@@ -276,11 +356,15 @@ func VarcharEncoderBytes(v []byte) pgx.Encoder {
 func (e *varcharEncoderBytes) FormatCode() int16 { return 0 }
 
 func (e *varcharEncoderBytes) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *varcharEncoderBytes) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != VarcharOid {
 		return fmt.Errorf("VarcharEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTextBytes(wbuf, e.v)
+	return encodeTextBytes(w, e.v)
 }
 
 type dateEncoder struct {
@@ -291,19 +375,24 @@ func DateEncoder(v time.Time) pgx.Encoder {
 	return &dateEncoder{v}
 }
 
-func (e *dateEncoder) FormatCode() int16 { return 0 }
+func (e *dateEncoder) FormatCode() int16 { return 1 }
 
 func (e *dateEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *dateEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != DateOid {
 		return fmt.Errorf("DateEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeDate(wbuf, e.v)
+	return encodeDate(w, e.v)
 }
 
-func encodeDate(wbuf *pgx.WriteBuf, v time.Time) error {
-	wbuf.WriteString(len10 + v.Format("2006-01-02"))
-	return nil
+// binary dates are days since 2000-01-01, for the calendar date of v in the
+// location of v
+func encodeDate(w ValueWriter, v time.Time) error {
+	return encodeInt4(w, daysOf(InfinityTimeOf(v)))
 }
 
 type timestampEncoder struct {
@@ -317,15 +406,21 @@ func TimestampEncoder(v time.Time) pgx.Encoder {
 func (e *timestampEncoder) FormatCode() int16 { return 1 }
 
 func (e *timestampEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timestampEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != TimestampOid {
 		return fmt.Errorf("TimestampEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTimestamp(wbuf, e.v)
+	return encodeTimestamp(w, e.v)
 }
 
-func encodeTimestamp(wbuf *pgx.WriteBuf, v time.Time) error {
-	return encodeTimestampTz(wbuf, v)
+// timestamps are encoded as the wall clock time of v in the location set by
+// SetLocation
+func encodeTimestamp(w ValueWriter, v time.Time) error {
+	return encodeInt8(w, microsOf(InfinityTimeOf(v), defaultLocation()))
 }
 
 type timestampTzEncoder struct {
@@ -339,22 +434,19 @@ func TimestampTzEncoder(v time.Time) pgx.Encoder {
 func (e *timestampTzEncoder) FormatCode() int16 { return 1 }
 
 func (e *timestampTzEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timestampTzEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != TimestampTzOid {
 		return fmt.Errorf("TimestampTzEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTimestampTz(wbuf, e.v)
+	return encodeTimestampTz(w, e.v)
 }
 
-func encodeTimestampTz(wbuf *pgx.WriteBuf, v time.Time) error {
-	microsecSinceUnixEpoch := v.Unix()*1000000 + int64(v.Nanosecond())/1000
-	microsecSinceY2K := microsecSinceUnixEpoch - microsecFromUnixEpochToY2K
-	x := microsecSinceY2K
-	b := []byte(len8)
-	b = append(b, byte(x>>56), byte(x>>48), byte(x>>40), byte(x>>32))
-	b = append(b, byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
-	wbuf.WriteBytes(b)
-	return nil
+func encodeTimestampTz(w ValueWriter, v time.Time) error {
+	return encodeInt8(w, microsOf(InfinityTimeOf(v), nil))
 }
 
 type oidEncoder struct {
@@ -368,15 +460,19 @@ func OidEncoder(v pgx.Oid) pgx.Encoder {
 func (e *oidEncoder) FormatCode() int16 { return 1 }
 
 func (e *oidEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *oidEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != OidOid {
 		return fmt.Errorf("OidEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeOid(wbuf, e.v)
+	return encodeOid(w, e.v)
 }
 
-func encodeOid(wbuf *pgx.WriteBuf, v pgx.Oid) error {
-	return encodeInt4(wbuf, int32(v))
+func encodeOid(w ValueWriter, v pgx.Oid) error {
+	return encodeInt4(w, int32(v))
 }
 
 func encodeArrayHeaderBytes(oid pgx.Oid, length, sizePerItem int) []byte {
@@ -401,17 +497,21 @@ func BoolArrayEncoder(v []bool) pgx.Encoder {
 func (e *boolArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *boolArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *boolArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != BoolArrayOid {
 		return fmt.Errorf("BoolArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeBoolArray(wbuf, e.v)
+	return encodeBoolArray(w, e.v)
 }
 
-func encodeBoolArray(wbuf *pgx.WriteBuf, vs []bool) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(BoolOid, len(vs), 5))
+func encodeBoolArray(w ValueWriter, vs []bool) error {
+	w.WriteBytes(encodeArrayHeaderBytes(BoolOid, len(vs), 5))
 	for _, v := range vs {
-		if err := encodeBool(wbuf, v); err != nil {
+		if err := encodeBool(w, v); err != nil {
 			return err
 		}
 	}
@@ -429,17 +529,21 @@ func Int2ArrayEncoder(v []int16) pgx.Encoder {
 func (e *int2ArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *int2ArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int2ArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int2ArrayOid {
 		return fmt.Errorf("Int2ArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt2Array(wbuf, e.v)
+	return encodeInt2Array(w, e.v)
 }
 
-func encodeInt2Array(wbuf *pgx.WriteBuf, vs []int16) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(Int2Oid, len(vs), 6))
+func encodeInt2Array(w ValueWriter, vs []int16) error {
+	w.WriteBytes(encodeArrayHeaderBytes(Int2Oid, len(vs), 6))
 	for _, v := range vs {
-		if err := encodeInt2(wbuf, v); err != nil {
+		if err := encodeInt2(w, v); err != nil {
 			return err
 		}
 	}
@@ -457,17 +561,21 @@ func Int4ArrayEncoder(v []int32) pgx.Encoder {
 func (e *int4ArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *int4ArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int4ArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int4ArrayOid {
 		return fmt.Errorf("Int4ArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt4Array(wbuf, e.v)
+	return encodeInt4Array(w, e.v)
 }
 
-func encodeInt4Array(wbuf *pgx.WriteBuf, vs []int32) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(Int4Oid, len(vs), 8))
+func encodeInt4Array(w ValueWriter, vs []int32) error {
+	w.WriteBytes(encodeArrayHeaderBytes(Int4Oid, len(vs), 8))
 	for _, v := range vs {
-		if err := encodeInt4(wbuf, v); err != nil {
+		if err := encodeInt4(w, v); err != nil {
 			return err
 		}
 	}
@@ -485,17 +593,21 @@ func Int8ArrayEncoder(v []int64) pgx.Encoder {
 func (e *int8ArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *int8ArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *int8ArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Int8ArrayOid {
 		return fmt.Errorf("Int8ArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeInt8Array(wbuf, e.v)
+	return encodeInt8Array(w, e.v)
 }
 
-func encodeInt8Array(wbuf *pgx.WriteBuf, vs []int64) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(Int8Oid, len(vs), 12))
+func encodeInt8Array(w ValueWriter, vs []int64) error {
+	w.WriteBytes(encodeArrayHeaderBytes(Int8Oid, len(vs), 12))
 	for _, v := range vs {
-		if err := encodeInt8(wbuf, v); err != nil {
+		if err := encodeInt8(w, v); err != nil {
 			return err
 		}
 	}
@@ -513,17 +625,21 @@ func Float4ArrayEncoder(v []float32) pgx.Encoder {
 func (e *float4ArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *float4ArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *float4ArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Float4ArrayOid {
 		return fmt.Errorf("Float4ArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeFloat4Array(wbuf, e.v)
+	return encodeFloat4Array(w, e.v)
 }
 
-func encodeFloat4Array(wbuf *pgx.WriteBuf, vs []float32) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(Int4Oid, len(vs), 8))
+func encodeFloat4Array(w ValueWriter, vs []float32) error {
+	w.WriteBytes(encodeArrayHeaderBytes(Int4Oid, len(vs), 8))
 	for _, v := range vs {
-		if err := encodeFloat4(wbuf, v); err != nil {
+		if err := encodeFloat4(w, v); err != nil {
 			return err
 		}
 	}
@@ -541,17 +657,21 @@ func Float8ArrayEncoder(v []float64) pgx.Encoder {
 func (e *float8ArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *float8ArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *float8ArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != Float8ArrayOid {
 		return fmt.Errorf("Float8ArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeFloat8Array(wbuf, e.v)
+	return encodeFloat8Array(w, e.v)
 }
 
-func encodeFloat8Array(wbuf *pgx.WriteBuf, vs []float64) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(Int8Oid, len(vs), 12))
+func encodeFloat8Array(w ValueWriter, vs []float64) error {
+	w.WriteBytes(encodeArrayHeaderBytes(Int8Oid, len(vs), 12))
 	for _, v := range vs {
-		if err := encodeFloat8(wbuf, v); err != nil {
+		if err := encodeFloat8(w, v); err != nil {
 			return err
 		}
 	}
@@ -569,18 +689,22 @@ func TextArrayEncoder(v []string) pgx.Encoder {
 func (e *textArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *textArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *textArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != TextArrayOid {
 		return fmt.Errorf("TextArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTextArray(wbuf, e.v)
+	return encodeTextArray(w, e.v)
 }
 
-func encodeTextArray(wbuf *pgx.WriteBuf, vs []string) error {
-	return encodeTextArrayGeneric(wbuf, vs, TextOid)
+func encodeTextArray(w ValueWriter, vs []string) error {
+	return encodeTextArrayGeneric(w, vs, TextOid)
 }
 
-func encodeTextArrayGeneric(wbuf *pgx.WriteBuf, vs []string, oid pgx.Oid) error {
+func encodeTextArrayGeneric(w ValueWriter, vs []string, oid pgx.Oid) error {
 	var totalStringSize int
 	for _, v := range vs {
 		totalStringSize += len(v)
@@ -593,8 +717,8 @@ func encodeTextArrayGeneric(wbuf *pgx.WriteBuf, vs []string, oid pgx.Oid) error
 	binary.BigEndian.PutUint32(header[12:16], uint32(oid))     // type of elements
 	binary.BigEndian.PutUint32(header[16:20], uint32(len(vs))) // number of elements
 	binary.BigEndian.PutUint32(header[20:24], 1)               // index of first element
-	wbuf.WriteBytes(header)
-	var enc func(*pgx.WriteBuf, string) error
+	w.WriteBytes(header)
+	var enc func(ValueWriter, string) error
 	switch oid {
 	default:
 		enc = encodeText
@@ -602,7 +726,7 @@ func encodeTextArrayGeneric(wbuf *pgx.WriteBuf, vs []string, oid pgx.Oid) error
 		enc = encodeVarchar
 	}
 	for _, v := range vs {
-		if err := enc(wbuf, v); err != nil {
+		if err := enc(w, v); err != nil {
 			return err
 		}
 	}
@@ -620,15 +744,19 @@ func VarcharArrayEncoder(v []string) pgx.Encoder {
 func (e *varcharArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *varcharArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *varcharArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != VarcharArrayOid {
 		return fmt.Errorf("VarcharArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeVarcharArray(wbuf, e.v)
+	return encodeVarcharArray(w, e.v)
 }
 
-func encodeVarcharArray(wbuf *pgx.WriteBuf, vs []string) error {
-	return encodeTextArrayGeneric(wbuf, vs, VarcharOid)
+func encodeVarcharArray(w ValueWriter, vs []string) error {
+	return encodeTextArrayGeneric(w, vs, VarcharOid)
 }
 
 type timestampArrayEncoder struct {
@@ -642,17 +770,21 @@ func TimestampArrayEncoder(v []time.Time) pgx.Encoder {
 func (e *timestampArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *timestampArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timestampArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != TimestampArrayOid {
 		return fmt.Errorf("TimestampArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTimestampArray(wbuf, e.v)
+	return encodeTimestampArray(w, e.v)
 }
 
-func encodeTimestampArray(wbuf *pgx.WriteBuf, vs []time.Time) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(TimestampOid, len(vs), 12))
+func encodeTimestampArray(w ValueWriter, vs []time.Time) error {
+	w.WriteBytes(encodeArrayHeaderBytes(TimestampOid, len(vs), 12))
 	for _, v := range vs {
-		if err := encodeTimestamp(wbuf, v); err != nil {
+		if err := encodeTimestamp(w, v); err != nil {
 			return err
 		}
 	}
@@ -670,17 +802,21 @@ func TimestampTzArrayEncoder(v []time.Time) pgx.Encoder {
 func (e *timestampTzArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *timestampTzArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timestampTzArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != TimestampTzArrayOid {
 		return fmt.Errorf("TimestampTzArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeTimestampTzArray(wbuf, e.v)
+	return encodeTimestampTzArray(w, e.v)
 }
 
-func encodeTimestampTzArray(wbuf *pgx.WriteBuf, vs []time.Time) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(TimestampTzOid, len(vs), 12))
+func encodeTimestampTzArray(w ValueWriter, vs []time.Time) error {
+	w.WriteBytes(encodeArrayHeaderBytes(TimestampTzOid, len(vs), 12))
 	for _, v := range vs {
-		if err := encodeTimestampTz(wbuf, v); err != nil {
+		if err := encodeTimestampTz(w, v); err != nil {
 			return err
 		}
 	}
@@ -703,16 +839,25 @@ func HstoreMapEncoder(v map[string]string) pgx.Encoder {
 func (e *hstoreEncoder) FormatCode() int16 { return 1 }
 
 func (e *hstoreEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
-	return encodeHstore(wbuf, e.v)
+	return e.EncodeValue(wbuf, oid)
 }
 
-func encodeHstore(wbuf *pgx.WriteBuf, kv pgx.Hstore) error {
-	wbuf.WriteInt32(int32(len(kv)))
+func (e *hstoreEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	return encodeHstore(w, e.v)
+}
+
+func encodeHstore(w ValueWriter, kv pgx.Hstore) error {
+	size := 4
+	for k, v := range kv {
+		size += 8 + len(k) + len(v)
+	}
+	w.WriteInt32(int32(size))
+	w.WriteInt32(int32(len(kv)))
 	for k, v := range kv {
-		wbuf.WriteInt32(int32(len(k)))
-		wbuf.WriteString(k)
-		wbuf.WriteInt32(int32(len(v)))
-		wbuf.WriteString(v)
+		w.WriteInt32(int32(len(k)))
+		w.WriteString(k)
+		w.WriteInt32(int32(len(v)))
+		w.WriteString(v)
 	}
 	return nil
 }
@@ -733,15 +878,19 @@ func UUIDEncoderString(v string) pgx.Encoder {
 func (e *uuidEncoder) FormatCode() int16 { return 1 }
 
 func (e *uuidEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *uuidEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != UUIDOid {
 		return fmt.Errorf("UUIDEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeUUID(wbuf, e.v)
+	return encodeUUID(w, e.v)
 }
 
-func encodeUUID(wbuf *pgx.WriteBuf, v uuid.UUID) error {
-	wbuf.WriteBytes(append([]byte(len16), v[:16]...))
+func encodeUUID(w ValueWriter, v uuid.UUID) error {
+	w.WriteBytes(append([]byte(len16), v[:16]...))
 	return nil
 }
 
@@ -756,17 +905,21 @@ func UUIDArrayEncoder(v []uuid.UUID) pgx.Encoder {
 func (e *uuidArrayEncoder) FormatCode() int16 { return 1 }
 
 func (e *uuidArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *uuidArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != UUIDArrayOid {
 		return fmt.Errorf("UUIDArrayEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeUUIDArray(wbuf, e.v)
+	return encodeUUIDArray(w, e.v)
 }
 
-func encodeUUIDArray(wbuf *pgx.WriteBuf, vs []uuid.UUID) error {
-	wbuf.WriteBytes(encodeArrayHeaderBytes(UUIDOid, len(vs), 16))
+func encodeUUIDArray(w ValueWriter, vs []uuid.UUID) error {
+	w.WriteBytes(encodeArrayHeaderBytes(UUIDOid, len(vs), 20))
 	for _, v := range vs {
-		if err := encodeUUID(wbuf, v); err != nil {
+		if err := encodeUUID(w, v); err != nil {
 			return err
 		}
 	}
@@ -774,30 +927,41 @@ func encodeUUIDArray(wbuf *pgx.WriteBuf, vs []uuid.UUID) error {
 }
 
 type jsonEncoder struct {
-	v interface{}
+	v     interface{}
+	codec string
 }
 
 func JSONEncoder(v interface{}) pgx.Encoder {
-	return &jsonEncoder{v}
+	return &jsonEncoder{v, ""}
+}
+
+// JSONEncoderCodec returns an encoder which marshals v with the JSON codec
+// registered under codec (see RegisterJSONCodec).
+func JSONEncoderCodec(v interface{}, codec string) pgx.Encoder {
+	return &jsonEncoder{v, codec}
 }
 
 func (e *jsonEncoder) FormatCode() int16 { return 0 }
 
 func (e *jsonEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != JSONOid {
 		return fmt.Errorf("JSONEncoder.Encode cannot encode into OID: %d", oid)
 	}
 
-	return encodeJSON(wbuf, e.v)
+	return encodeJSON(w, e.v, e.codec)
 }
 
-func encodeJSON(wbuf *pgx.WriteBuf, v interface{}) error {
-	b, err := json.Marshal(v)
+func encodeJSON(w ValueWriter, v interface{}, codec string) error {
+	b, err := marshalJSON(codec, v)
 	if err != nil {
 		return err
 	}
-	wbuf.WriteInt32(int32(len(b)))
-	wbuf.WriteBytes(b)
+	w.WriteInt32(int32(len(b)))
+	w.WriteBytes(b)
 	return nil
 }
 
@@ -812,11 +976,15 @@ func JSONEncoderString(v string) pgx.Encoder {
 func (e *jsonEncoderString) FormatCode() int16 { return 0 }
 
 func (e *jsonEncoderString) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonEncoderString) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != JSONOid {
 		return fmt.Errorf("JSONEncoder.Encode cannot encode into OID: %d", oid)
 	}
-	wbuf.WriteInt32(int32(len(e.v)))
-	wbuf.WriteString(e.v)
+	w.WriteInt32(int32(len(e.v)))
+	w.WriteString(e.v)
 	return nil
 }
 
@@ -831,10 +999,103 @@ func JSONEncoderBytes(v []byte) pgx.Encoder {
 func (e *jsonEncoderBytes) FormatCode() int16 { return 0 }
 
 func (e *jsonEncoderBytes) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonEncoderBytes) EncodeValue(w ValueWriter, oid pgx.Oid) error {
 	if oid != JSONOid {
 		return fmt.Errorf("JSONEncoder.Encode cannot encode into OID: %d", oid)
 	}
-	wbuf.WriteInt32(int32(len(e.v)))
-	wbuf.WriteBytes(e.v)
+	w.WriteInt32(int32(len(e.v)))
+	w.WriteBytes(e.v)
+	return nil
+}
+
+// jsonbVersion is the version prefix of the binary jsonb format
+const jsonbVersion = 1
+
+type jsonbEncoder struct {
+	v     interface{}
+	codec string
+}
+
+func JSONBEncoder(v interface{}) pgx.Encoder {
+	return &jsonbEncoder{v, ""}
+}
+
+// JSONBEncoderCodec returns an encoder which marshals v with the JSON codec
+// registered under codec (see RegisterJSONCodec).
+func JSONBEncoderCodec(v interface{}, codec string) pgx.Encoder {
+	return &jsonbEncoder{v, codec}
+}
+
+func (e *jsonbEncoder) FormatCode() int16 { return 1 }
+
+func (e *jsonbEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonbEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != JSONBOid {
+		return fmt.Errorf("JSONBEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	b, err := marshalJSON(e.codec, e.v)
+	if err != nil {
+		return err
+	}
+	return encodeJSONB(w, b)
+}
+
+func encodeJSONB(w ValueWriter, b []byte) error {
+	w.WriteInt32(int32(1 + len(b)))
+	w.WriteBytes([]byte{jsonbVersion})
+	w.WriteBytes(b)
+	return nil
+}
+
+type jsonbEncoderString struct {
+	v string
+}
+
+func JSONBEncoderString(v string) pgx.Encoder {
+	return &jsonbEncoderString{v}
+}
+
+func (e *jsonbEncoderString) FormatCode() int16 { return 1 }
+
+func (e *jsonbEncoderString) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonbEncoderString) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != JSONBOid {
+		return fmt.Errorf("JSONBEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	w.WriteInt32(int32(1 + len(e.v)))
+	w.WriteBytes([]byte{jsonbVersion})
+	w.WriteString(e.v)
 	return nil
 }
+
+type jsonbEncoderBytes struct {
+	v []byte
+}
+
+func JSONBEncoderBytes(v []byte) pgx.Encoder {
+	return &jsonbEncoderBytes{v}
+}
+
+func (e *jsonbEncoderBytes) FormatCode() int16 { return 1 }
+
+func (e *jsonbEncoderBytes) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *jsonbEncoderBytes) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != JSONBOid {
+		return fmt.Errorf("JSONBEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	return encodeJSONB(w, e.v)
+}
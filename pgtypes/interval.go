@@ -0,0 +1,262 @@
+package pgtypes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+// Interval holds an interval value with each of its components. Months and days
+// have no fixed duration, so they are kept apart from the time component.
+type Interval struct {
+	Microseconds int64
+	Days         int32
+	Months       int32
+}
+
+// Duration returns the duration of v, or an error if v has a non-zero number of
+// months or days.
+func (v Interval) Duration() (time.Duration, error) {
+	if v.Months != 0 || v.Days != 0 {
+		return 0, fmt.Errorf("Interval with %d months and %d days cannot be represented as a time.Duration", v.Months, v.Days)
+	}
+	return time.Duration(v.Microseconds) * time.Microsecond, nil
+}
+
+// DurationInterval returns an interval for d, truncated to microseconds.
+func DurationInterval(d time.Duration) Interval {
+	return Interval{Microseconds: int64(d / time.Microsecond)}
+}
+
+func (v Interval) String() string {
+	return fmt.Sprintf("%d months %d days %s", v.Months, v.Days, time.Duration(v.Microseconds)*time.Microsecond)
+}
+
+type intervalEncoder struct {
+	v Interval
+}
+
+// IntervalEncoder returns an encoder which writes v as an interval value.
+func IntervalEncoder(v Interval) pgx.Encoder {
+	return &intervalEncoder{v}
+}
+
+// IntervalEncoderDuration returns an encoder which writes v as an interval
+// value, truncated to microseconds.
+func IntervalEncoderDuration(v time.Duration) pgx.Encoder {
+	return &intervalEncoder{DurationInterval(v)}
+}
+
+func (e *intervalEncoder) FormatCode() int16 { return 1 }
+
+func (e *intervalEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *intervalEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != IntervalOid {
+		return fmt.Errorf("IntervalEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	return encodeInterval(w, e.v)
+}
+
+func encodeInterval(w ValueWriter, v Interval) error {
+	x, d, m := v.Microseconds, v.Days, v.Months
+	b := []byte(len16)
+	b = append(b, byte(x>>56), byte(x>>48), byte(x>>40), byte(x>>32))
+	b = append(b, byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+	b = append(b, byte(d>>24), byte(d>>16), byte(d>>8), byte(d))
+	b = append(b, byte(m>>24), byte(m>>16), byte(m>>8), byte(m))
+	w.WriteBytes(b)
+	return nil
+}
+
+type intervalScanner struct {
+	v *Interval
+}
+
+func IntervalScanner(v *Interval) pgx.Scanner {
+	return intervalScanner{v}
+}
+
+func (s intervalScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s intervalScanner) ScanValue(vr ValueReader) error {
+	*s.v = decodeInterval(vr)
+	return vr.Err()
+}
+
+type intervalScannerDuration struct {
+	v *time.Duration
+}
+
+// IntervalScannerDuration returns a scanner which decodes interval values into
+// v. Intervals with a non-zero number of months or days cannot be decoded.
+func IntervalScannerDuration(v *time.Duration) pgx.Scanner {
+	return intervalScannerDuration{v}
+}
+
+func (s intervalScannerDuration) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s intervalScannerDuration) ScanValue(vr ValueReader) error {
+	v := decodeInterval(vr)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	d, err := v.Duration()
+	if err != nil {
+		vr.Fatal(err)
+		return err
+	}
+	*s.v = d
+	return nil
+}
+
+func decodeInterval(vr ValueReader) Interval {
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into interval"))
+		return Interval{}
+	}
+
+	if vr.Type().DataType != IntervalOid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into interval", vr.Type().DataType)))
+		return Interval{}
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return Interval{}
+	}
+
+	if vr.Len() != 16 {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for an interval: %d", vr.Len())))
+		return Interval{}
+	}
+
+	return decodeIntervalBinary(vr)
+}
+
+// decode a binary interval, following its length prefix
+func decodeIntervalBinary(vr ValueReader) Interval {
+	var v Interval
+	v.Microseconds = vr.ReadInt64()
+	v.Days = vr.ReadInt32()
+	v.Months = vr.ReadInt32()
+	return v
+}
+
+type intervalArrayEncoder struct {
+	v []Interval
+}
+
+func IntervalArrayEncoder(v []Interval) pgx.Encoder {
+	return &intervalArrayEncoder{v}
+}
+
+// IntervalArrayEncoderDuration returns an encoder which writes v as an
+// interval[] value, with each element truncated to microseconds.
+func IntervalArrayEncoderDuration(v []time.Duration) pgx.Encoder {
+	a := make([]Interval, len(v))
+	for i, d := range v {
+		a[i] = DurationInterval(d)
+	}
+	return &intervalArrayEncoder{a}
+}
+
+func (e *intervalArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *intervalArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *intervalArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != IntervalArrayOid {
+		return fmt.Errorf("IntervalArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+
+	w.WriteBytes(encodeArrayHeaderBytes(IntervalOid, len(e.v), 20))
+	for _, v := range e.v {
+		if err := encodeInterval(w, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type intervalArrayScanner struct {
+	v *[]Interval
+}
+
+func IntervalArrayScanner(v *[]Interval) pgx.Scanner {
+	return intervalArrayScanner{v}
+}
+
+func (s intervalArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s intervalArrayScanner) ScanValue(vr ValueReader) error {
+	*s.v = decodeIntervalArray(vr)
+	return vr.Err()
+}
+
+type intervalArrayScannerDuration struct {
+	v *[]time.Duration
+}
+
+// IntervalArrayScannerDuration returns a scanner which decodes interval[] values
+// into v. Elements with a non-zero number of months or days cannot be decoded.
+func IntervalArrayScannerDuration(v *[]time.Duration) pgx.Scanner {
+	return intervalArrayScannerDuration{v}
+}
+
+func (s intervalArrayScannerDuration) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s intervalArrayScannerDuration) ScanValue(vr ValueReader) error {
+	vs := decodeIntervalArray(vr)
+	if vr.Err() != nil || vs == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]time.Duration, len(vs))
+	for i, v := range vs {
+		d, err := v.Duration()
+		if err != nil {
+			vr.Fatal(err)
+			return err
+		}
+		a[i] = d
+	}
+	*s.v = a
+	return nil
+}
+
+func decodeIntervalArray(vr ValueReader) []Interval {
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	numElems := decodeArrayHeader(vr, IntervalArrayOid, "[]pgtypes.Interval")
+	if vr.Err() != nil {
+		return nil
+	}
+
+	a := make([]Interval, numElems)
+	for i := 0; i < len(a); i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil || !checkArrayElementSize(vr, elSize, 16, "interval") {
+			return nil
+		}
+		a[i] = decodeIntervalBinary(vr)
+	}
+
+	return a
+}
@@ -0,0 +1,52 @@
+package pgtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestInterval(t *testing.T) {
+	iv := Interval{Microseconds: -1500000, Days: 3, Months: 14}
+	oids := []pgx.Oid{IntervalOid, IntervalOid, IntervalOid, IntervalArrayOid, IntervalArrayOid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		IntervalEncoder(iv),
+		IntervalEncoderDuration(90*time.Minute + 1500*time.Nanosecond),
+		IntervalEncoder(iv),
+		IntervalArrayEncoderDuration([]time.Duration{time.Second, -time.Hour}),
+		IntervalArrayEncoder([]Interval{iv}),
+	})
+	var got Interval
+	if err := scanField(t, cr, IntervalOid, IntervalScanner(&got)); err != nil || got != iv {
+		t.Fatal(err, got)
+	}
+	// durations are truncated to microseconds
+	var d time.Duration
+	if err := scanField(t, cr, IntervalOid, IntervalScannerDuration(&d)); err != nil || d != 90*time.Minute+time.Microsecond {
+		t.Fatal(err, d)
+	}
+	if err := scanField(t, cr, IntervalOid, IntervalScannerDuration(&d)); err == nil {
+		t.Fatal("expected an error scanning an interval with days or months into a duration")
+	}
+	var ds []time.Duration
+	if err := scanField(t, cr, IntervalArrayOid, IntervalArrayScannerDuration(&ds)); err != nil || len(ds) != 2 || ds[0] != time.Second || ds[1] != -time.Hour {
+		t.Fatal(err, ds)
+	}
+	var ivs []Interval
+	if err := scanField(t, cr, IntervalArrayOid, IntervalArrayScanner(&ivs)); err != nil || len(ivs) != 1 || ivs[0] != iv {
+		t.Fatal(err, ivs)
+	}
+}
+
+func TestIntervalEncoding(t *testing.T) {
+	var buf copyBuf
+	if err := IntervalEncoder(Interval{Microseconds: 1, Days: -2, Months: 3}).(ValueEncoder).EncodeValue(&buf, IntervalOid); err != nil {
+		t.Fatal(err)
+	}
+	// microseconds, then days, then months
+	want := []byte{0, 0, 0, 16, 0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xfe, 0, 0, 0, 3}
+	if string(buf) != string(want) {
+		t.Fatalf("encoded as %x; want %x", []byte(buf), want)
+	}
+}
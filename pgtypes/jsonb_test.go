@@ -0,0 +1,55 @@
+package pgtypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestJSONB(t *testing.T) {
+	cr := copyRow(t, []pgx.Oid{JSONBOid, JSONBOid, JSONBOid, JSONBArrayOid, JSONBArrayOid}, []pgx.Encoder{
+		JSONBEncoder(map[string]int{"a": 1}),
+		JSONBEncoderString(`{"b":2}`),
+		JSONBEncoderBytes([]byte(`[1]`)),
+		JSONBArrayEncoderString([]string{`{"x":1}`, `[2]`}),
+		JSONBArrayEncoderRaw([]json.RawMessage{json.RawMessage(`3`)}),
+	})
+	var m map[string]int
+	if err := scanField(t, cr, JSONBOid, JSONBScanner(&m)); err != nil || m["a"] != 1 {
+		t.Fatal(err, m)
+	}
+	var s string
+	if err := scanField(t, cr, JSONBOid, JSONBScannerString(&s)); err != nil || s != `{"b":2}` {
+		t.Fatal(err, s)
+	}
+	var b []byte
+	if err := scanField(t, cr, JSONBOid, JSONBScannerBytes(&b)); err != nil || string(b) != `[1]` {
+		t.Fatal(err, b)
+	}
+	var ss []string
+	if err := scanField(t, cr, JSONBArrayOid, JSONBArrayScannerString(&ss)); err != nil || len(ss) != 2 || ss[1] != `[2]` {
+		t.Fatal(err, ss)
+	}
+	var raw []json.RawMessage
+	if err := scanField(t, cr, JSONBArrayOid, JSONBArrayScannerRaw(&raw)); err != nil || len(raw) != 1 || string(raw[0]) != `3` {
+		t.Fatal(err, raw)
+	}
+}
+
+func TestJSONBVersion(t *testing.T) {
+	var buf copyBuf
+	if err := JSONBEncoderString(`{}`).(ValueEncoder).EncodeValue(&buf, JSONBOid); err != nil {
+		t.Fatal(err)
+	}
+	// binary jsonb values are prefixed with a version number of 1
+	if want := []byte{0, 0, 0, 3, 1, '{', '}'}; string(buf) != string(want) {
+		t.Fatalf("encoded as %x; want %x", []byte(buf), want)
+	}
+
+	buf[4] = 2
+	var s string
+	if err := JSONBScannerString(&s).(ValueScanner).ScanValue(binaryValue(JSONBOid, buf)); err == nil {
+		t.Fatal("expected an error for an unknown jsonb version")
+	}
+}
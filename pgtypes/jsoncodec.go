@@ -0,0 +1,124 @@
+package pgtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// JSONCodec marshals Go values into JSON and unmarshals JSON into Go values,
+// for the json and jsonb encoders and scanners within this package.
+type JSONCodec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// Names of the built-in JSON codecs (see RegisterJSONCodec)
+const (
+	// encoding/json, with the default options
+	JSONCodecStd = "std"
+	// encoding/json, returning an error for unknown object keys
+	JSONCodecStrict = "strict"
+	// encoding/json, unmarshaling numbers into interface{} values as
+	// json.Number
+	JSONCodecNumber = "number"
+)
+
+// StdJSONCodec is a JSONCodec which uses encoding/json. If DisallowUnknownFields
+// is true, unmarshaling an object with a key which does not match a field of
+// the destination struct will fail. If UseNumber is true, numbers will be
+// unmarshaled into interface{} values as json.Number.
+type StdJSONCodec struct {
+	DisallowUnknownFields bool
+	UseNumber             bool
+}
+
+func (c StdJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (c StdJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	if !c.DisallowUnknownFields && !c.UseNumber {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if c.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	if c.UseNumber {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return fmt.Errorf("Unexpected data after the JSON value")
+	}
+	return nil
+}
+
+var jsonCodecs = struct {
+	sync.RWMutex
+	dflt  JSONCodec
+	named map[string]JSONCodec
+}{
+	dflt: StdJSONCodec{},
+	named: map[string]JSONCodec{
+		JSONCodecStd:    StdJSONCodec{},
+		JSONCodecStrict: StdJSONCodec{DisallowUnknownFields: true},
+		JSONCodecNumber: StdJSONCodec{UseNumber: true},
+	},
+}
+
+// SetJSONCodec sets the codec used by JSONEncoder, JSONScanner, JSONBEncoder
+// and JSONBScanner, and by the codec-named variants when given an empty name.
+// The codec must be safe for concurrent use. A nil codec restores the default
+// StdJSONCodec.
+func SetJSONCodec(codec JSONCodec) {
+	if codec == nil {
+		codec = StdJSONCodec{}
+	}
+	jsonCodecs.Lock()
+	jsonCodecs.dflt = codec
+	jsonCodecs.Unlock()
+}
+
+// RegisterJSONCodec registers codec under name, for columns tagged with the
+// json:{name} option (see JSONEncoderCodec and JSONScannerCodec). The codec must
+// be safe for concurrent use.
+func RegisterJSONCodec(name string, codec JSONCodec) {
+	jsonCodecs.Lock()
+	jsonCodecs.named[name] = codec
+	jsonCodecs.Unlock()
+}
+
+// GetJSONCodec returns the codec registered under name, or the codec set by
+// SetJSONCodec if name is empty.
+func GetJSONCodec(name string) (JSONCodec, error) {
+	jsonCodecs.RLock()
+	defer jsonCodecs.RUnlock()
+	if name == "" {
+		return jsonCodecs.dflt, nil
+	}
+	if codec := jsonCodecs.named[name]; codec != nil {
+		return codec, nil
+	}
+	return nil, fmt.Errorf("No JSON codec registered with name: %s", name)
+}
+
+func marshalJSON(codec string, v interface{}) ([]byte, error) {
+	c, err := GetJSONCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+	return c.Marshal(v)
+}
+
+func unmarshalJSON(codec string, b []byte, v interface{}) error {
+	c, err := GetJSONCodec(codec)
+	if err != nil {
+		return err
+	}
+	return c.Unmarshal(b, v)
+}
@@ -0,0 +1,385 @@
+package pgtypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+// The encoders and scanners within this file support multi-dimensional arrays,
+// which are encoded from and decoded into nested slices (e.g. [][]int32 for a
+// two-dimensional int4[] value). Nested slices must be rectangular, and must
+// have as many levels as the array has dimensions. Lower bounds other than 1
+// are accepted when decoding, but are not preserved.
+
+// an element type of multi-dimensional arrays
+type arrayElem struct {
+	name string
+	typ  reflect.Type
+	// the size of each element, or 0 if elements vary in size
+	size int32
+	// write the length-prefixed element v
+	enc func(w ValueWriter, v interface{}) error
+	// read an element of the given size, following its length prefix
+	dec func(vr ValueReader, size int32) interface{}
+}
+
+var (
+	boolArrayElem = &arrayElem{
+		name: "bool", typ: reflect.TypeOf(false), size: 1,
+		enc: func(w ValueWriter, v interface{}) error { return encodeBool(w, v.(bool)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadUint8() == 1 },
+	}
+	int2ArrayElem = &arrayElem{
+		name: "int2", typ: reflect.TypeOf(int16(0)), size: 2,
+		enc: func(w ValueWriter, v interface{}) error { return encodeInt2(w, v.(int16)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadInt16() },
+	}
+	int4ArrayElem = &arrayElem{
+		name: "int4", typ: reflect.TypeOf(int32(0)), size: 4,
+		enc: func(w ValueWriter, v interface{}) error { return encodeInt4(w, v.(int32)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadInt32() },
+	}
+	int8ArrayElem = &arrayElem{
+		name: "int8", typ: reflect.TypeOf(int64(0)), size: 8,
+		enc: func(w ValueWriter, v interface{}) error { return encodeInt8(w, v.(int64)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadInt64() },
+	}
+	float4ArrayElem = &arrayElem{
+		name: "float4", typ: reflect.TypeOf(float32(0)), size: 4,
+		enc: func(w ValueWriter, v interface{}) error { return encodeFloat4(w, v.(float32)) },
+		dec: func(vr ValueReader, size int32) interface{} { return math.Float32frombits(uint32(vr.ReadInt32())) },
+	}
+	float8ArrayElem = &arrayElem{
+		name: "float8", typ: reflect.TypeOf(float64(0)), size: 8,
+		enc: func(w ValueWriter, v interface{}) error { return encodeFloat8(w, v.(float64)) },
+		dec: func(vr ValueReader, size int32) interface{} { return math.Float64frombits(uint64(vr.ReadInt64())) },
+	}
+	textArrayElem = &arrayElem{
+		name: "text", typ: reflect.TypeOf(""), size: 0,
+		enc: func(w ValueWriter, v interface{}) error { return encodeText(w, v.(string)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadString(size) },
+	}
+	varcharArrayElem = &arrayElem{
+		name: "varchar", typ: reflect.TypeOf(""), size: 0,
+		enc: func(w ValueWriter, v interface{}) error { return encodeVarchar(w, v.(string)) },
+		dec: func(vr ValueReader, size int32) interface{} { return vr.ReadString(size) },
+	}
+	timestampArrayElem = &arrayElem{
+		name: "timestamp", typ: reflect.TypeOf(time.Time{}), size: 8,
+		enc: func(w ValueWriter, v interface{}) error { return encodeTimestamp(w, v.(time.Time)) },
+		dec: func(vr ValueReader, size int32) interface{} { return decodeTimestampBinary(vr) },
+	}
+	timestampTzArrayElem = &arrayElem{
+		name: "timestamptz", typ: reflect.TypeOf(time.Time{}), size: 8,
+		enc: func(w ValueWriter, v interface{}) error { return encodeTimestampTz(w, v.(time.Time)) },
+		dec: func(vr ValueReader, size int32) interface{} { return decodeTimestampTzBinary(vr) },
+	}
+	dateArrayElem = &arrayElem{
+		name: "date", typ: reflect.TypeOf(time.Time{}), size: 4,
+		enc: func(w ValueWriter, v interface{}) error { return encodeDate(w, v.(time.Time)) },
+		dec: func(vr ValueReader, size int32) interface{} { return decodeDateBinary(vr) },
+	}
+)
+
+type multiArrayEncoder struct {
+	name              string
+	arrayOid, elemOid pgx.Oid
+	elem              *arrayElem
+	v                 interface{}
+}
+
+func (e *multiArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *multiArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *multiArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.arrayOid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", e.name, oid)
+	}
+
+	rv := reflect.ValueOf(e.v)
+	depth := arrayDepth(rv.Type(), e.elem.typ)
+	if depth == 0 {
+		return fmt.Errorf("%s.Encode cannot encode %T as a %s array", e.name, e.v, e.elem.name)
+	}
+	// the length of each dimension is taken from the first slice at each level:
+	dims := make([]int, depth)
+	total := 1
+	for d, cur := 0, rv; d < depth; d++ {
+		dims[d] = cur.Len()
+		total *= dims[d]
+		if dims[d] == 0 {
+			break
+		}
+		cur = cur.Index(0)
+	}
+
+	var elems copyBuf
+	if err := e.encodeLevel(&elems, rv, dims); err != nil {
+		return err
+	}
+	// arrays with no elements have no dimensions:
+	if total == 0 {
+		dims = nil
+	}
+	header := make([]byte, 16+8*len(dims))
+	binary.BigEndian.PutUint32(header[:4], uint32(12+8*len(dims)+len(elems)))
+	binary.BigEndian.PutUint32(header[4:8], uint32(len(dims)))   // number of dimensions
+	binary.BigEndian.PutUint32(header[8:12], 0)                  // no nulls
+	binary.BigEndian.PutUint32(header[12:16], uint32(e.elemOid)) // type of elements
+	for d, n := range dims {
+		binary.BigEndian.PutUint32(header[16+8*d:], uint32(n)) // number of elements
+		binary.BigEndian.PutUint32(header[20+8*d:], 1)         // index of first element
+	}
+	w.WriteBytes(header)
+	w.WriteBytes(elems)
+	return nil
+}
+
+// write the elements of the slice v, whose dimensions must match dims
+func (e *multiArrayEncoder) encodeLevel(w ValueWriter, v reflect.Value, dims []int) error {
+	if v.Len() != dims[0] {
+		return fmt.Errorf("%s.Encode cannot encode a non-rectangular array (expected a length of %d, but found %d)", e.name, dims[0], v.Len())
+	}
+	for i := 0; i < v.Len(); i++ {
+		var err error
+		if len(dims) == 1 {
+			err = e.elem.enc(w, v.Index(i).Interface())
+		} else {
+			err = e.encodeLevel(w, v.Index(i), dims[1:])
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the number of nested slice levels of t above elements of type elem, or 0 if
+// t is not a nested slice of elem
+func arrayDepth(t, elem reflect.Type) int {
+	depth := 0
+	for t.Kind() == reflect.Slice {
+		depth, t = depth+1, t.Elem()
+		if t == elem {
+			return depth
+		}
+	}
+	return 0
+}
+
+type multiArrayScanner struct {
+	arrayOid pgx.Oid
+	elem     *arrayElem
+	v        interface{}
+}
+
+func (s *multiArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s *multiArrayScanner) ScanValue(vr ValueReader) error {
+	rv := reflect.ValueOf(s.v)
+	if rv.Kind() != reflect.Ptr || arrayDepth(rv.Type().Elem(), s.elem.typ) == 0 {
+		vr.Fatal(fmt.Errorf("Cannot decode a %s array into %T", s.elem.name, s.v))
+		return vr.Err()
+	}
+	slice := rv.Elem()
+	slice.Set(reflect.Zero(slice.Type()))
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	if vr.Type().DataType != s.arrayOid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into %s", vr.Type().DataType, slice.Type())))
+		return vr.Err()
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return vr.Err()
+	}
+
+	numDims := vr.ReadInt32()
+	vr.ReadInt32() // 0 if no nulls / 1 if there is one or more nulls
+	vr.ReadInt32() // element oid
+	if numDims == 0 {
+		return vr.Err()
+	}
+	if depth := arrayDepth(slice.Type(), s.elem.typ); int(numDims) != depth {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode an array with %d dimensions into %s", numDims, slice.Type())))
+		return vr.Err()
+	}
+	dims := make([]int, numDims)
+	for d := range dims {
+		dims[d] = int(vr.ReadInt32())
+		vr.ReadInt32() // lower bound, which cannot be represented by a slice
+		if dims[d] < 0 {
+			vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid array dimension: %d", dims[d])))
+			return vr.Err()
+		}
+	}
+
+	a := s.decodeLevel(vr, slice.Type(), dims)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	slice.Set(a)
+	return nil
+}
+
+// read the elements of a slice of type t, with the given dimensions
+func (s *multiArrayScanner) decodeLevel(vr ValueReader, t reflect.Type, dims []int) reflect.Value {
+	a := reflect.MakeSlice(t, dims[0], dims[0])
+	for i := 0; i < dims[0] && vr.Err() == nil; i++ {
+		if len(dims) > 1 {
+			a.Index(i).Set(s.decodeLevel(vr, t.Elem(), dims[1:]))
+			continue
+		}
+		size := decodeArrayElementSize(vr)
+		if vr.Err() != nil || !checkArrayElementSize(vr, size, s.elem.size, s.elem.name) {
+			break
+		}
+		a.Index(i).Set(reflect.ValueOf(s.elem.dec(vr, size)))
+	}
+	return a
+}
+
+// BoolArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of bool (e.g. [][]bool), as a multi-dimensional bool[] value.
+func BoolArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"BoolArrayEncoder", BoolArrayOid, BoolOid, boolArrayElem, v}
+}
+
+// BoolArrayScannerMulti returns a scanner which decodes multi-dimensional bool[]
+// values into v, a pointer to a nested slice of bool (e.g. *[][]bool).
+func BoolArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{BoolArrayOid, boolArrayElem, v}
+}
+
+// Int2ArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of int16 (e.g. [][]int16), as a multi-dimensional int2[] value.
+func Int2ArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"Int2ArrayEncoder", Int2ArrayOid, Int2Oid, int2ArrayElem, v}
+}
+
+// Int2ArrayScannerMulti returns a scanner which decodes multi-dimensional int2[]
+// values into v, a pointer to a nested slice of int16 (e.g. *[][]int16).
+func Int2ArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{Int2ArrayOid, int2ArrayElem, v}
+}
+
+// Int4ArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of int32 (e.g. [][]int32), as a multi-dimensional int4[] value.
+func Int4ArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"Int4ArrayEncoder", Int4ArrayOid, Int4Oid, int4ArrayElem, v}
+}
+
+// Int4ArrayScannerMulti returns a scanner which decodes multi-dimensional int4[]
+// values into v, a pointer to a nested slice of int32 (e.g. *[][]int32).
+func Int4ArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{Int4ArrayOid, int4ArrayElem, v}
+}
+
+// Int8ArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of int64 (e.g. [][]int64), as a multi-dimensional int8[] value.
+func Int8ArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"Int8ArrayEncoder", Int8ArrayOid, Int8Oid, int8ArrayElem, v}
+}
+
+// Int8ArrayScannerMulti returns a scanner which decodes multi-dimensional int8[]
+// values into v, a pointer to a nested slice of int64 (e.g. *[][]int64).
+func Int8ArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{Int8ArrayOid, int8ArrayElem, v}
+}
+
+// Float4ArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of float32 (e.g. [][]float32), as a multi-dimensional float4[] value.
+func Float4ArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"Float4ArrayEncoder", Float4ArrayOid, Float4Oid, float4ArrayElem, v}
+}
+
+// Float4ArrayScannerMulti returns a scanner which decodes multi-dimensional float4[]
+// values into v, a pointer to a nested slice of float32 (e.g. *[][]float32).
+func Float4ArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{Float4ArrayOid, float4ArrayElem, v}
+}
+
+// Float8ArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of float64 (e.g. [][]float64), as a multi-dimensional float8[] value.
+func Float8ArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"Float8ArrayEncoder", Float8ArrayOid, Float8Oid, float8ArrayElem, v}
+}
+
+// Float8ArrayScannerMulti returns a scanner which decodes multi-dimensional float8[]
+// values into v, a pointer to a nested slice of float64 (e.g. *[][]float64).
+func Float8ArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{Float8ArrayOid, float8ArrayElem, v}
+}
+
+// TextArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of string (e.g. [][]string), as a multi-dimensional text[] value.
+func TextArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"TextArrayEncoder", TextArrayOid, TextOid, textArrayElem, v}
+}
+
+// TextArrayScannerMulti returns a scanner which decodes multi-dimensional text[]
+// values into v, a pointer to a nested slice of string (e.g. *[][]string).
+func TextArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{TextArrayOid, textArrayElem, v}
+}
+
+// VarcharArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of string (e.g. [][]string), as a multi-dimensional varchar[] value.
+func VarcharArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"VarcharArrayEncoder", VarcharArrayOid, VarcharOid, varcharArrayElem, v}
+}
+
+// VarcharArrayScannerMulti returns a scanner which decodes multi-dimensional varchar[]
+// values into v, a pointer to a nested slice of string (e.g. *[][]string).
+func VarcharArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{VarcharArrayOid, varcharArrayElem, v}
+}
+
+// TimestampArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of time.Time (e.g. [][]time.Time), as a multi-dimensional timestamp[] value.
+func TimestampArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"TimestampArrayEncoder", TimestampArrayOid, TimestampOid, timestampArrayElem, v}
+}
+
+// TimestampArrayScannerMulti returns a scanner which decodes multi-dimensional timestamp[]
+// values into v, a pointer to a nested slice of time.Time (e.g. *[][]time.Time).
+func TimestampArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{TimestampArrayOid, timestampArrayElem, v}
+}
+
+// TimestampTzArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of time.Time (e.g. [][]time.Time), as a multi-dimensional timestamptz[] value.
+func TimestampTzArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"TimestampTzArrayEncoder", TimestampTzArrayOid, TimestampTzOid, timestampTzArrayElem, v}
+}
+
+// TimestampTzArrayScannerMulti returns a scanner which decodes multi-dimensional timestamptz[]
+// values into v, a pointer to a nested slice of time.Time (e.g. *[][]time.Time).
+func TimestampTzArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{TimestampTzArrayOid, timestampTzArrayElem, v}
+}
+
+// DateArrayEncoderMulti returns an encoder which writes v, a rectangular nested
+// slice of time.Time (e.g. [][]time.Time), as a multi-dimensional date[] value.
+func DateArrayEncoderMulti(v interface{}) pgx.Encoder {
+	return &multiArrayEncoder{"DateArrayEncoder", DateArrayOid, DateOid, dateArrayElem, v}
+}
+
+// DateArrayScannerMulti returns a scanner which decodes multi-dimensional date[]
+// values into v, a pointer to a nested slice of time.Time (e.g. *[][]time.Time).
+func DateArrayScannerMulti(v interface{}) pgx.Scanner {
+	return &multiArrayScanner{DateArrayOid, dateArrayElem, v}
+}
@@ -0,0 +1,57 @@
+package pgtypes
+
+import (
+	"testing"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestMultiArrays(t *testing.T) {
+	oids := []pgx.Oid{Int4ArrayOid, TextArrayOid, Int4ArrayOid, Int4ArrayOid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		Int4ArrayEncoderMulti([][]int32{{1, 2, 3}, {4, 5, 6}}),
+		TextArrayEncoderMulti([][][]string{{{"a"}, {"b"}}}),
+		Int4ArrayEncoderMulti([][]int32{{}, {}}),
+		Int4ArrayEncoder([]int32{9}),
+	})
+	var m [][]int32
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScannerMulti(&m)); err != nil || len(m) != 2 || len(m[0]) != 3 || m[0][0] != 1 || m[1][2] != 6 {
+		t.Fatal(err, m)
+	}
+	var s [][][]string
+	if err := scanField(t, cr, TextArrayOid, TextArrayScannerMulti(&s)); err != nil || len(s) != 1 || len(s[0]) != 2 || s[0][1][0] != "b" {
+		t.Fatal(err, s)
+	}
+	// empty arrays have no dimensions
+	var empty [][]int32
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScannerMulti(&empty)); err != nil || empty != nil {
+		t.Fatal(err, empty)
+	}
+	var wrong [][]int32
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScannerMulti(&wrong)); err == nil {
+		t.Fatal("expected an error scanning a 1-dimensional array into a 2-dimensional slice")
+	}
+}
+
+func TestMultiArrayErrors(t *testing.T) {
+	if err := Int4ArrayEncoderMulti([][]int32{{1}, {2, 3}}).(ValueEncoder).EncodeValue(&copyBuf{}, Int4ArrayOid); err == nil {
+		t.Fatal("expected an error encoding a non-rectangular array")
+	}
+}
+
+func TestArrayLowerBound(t *testing.T) {
+	// '[0:1]={7,8}'::int4[] is decoded regardless of its lower bound
+	var buf copyBuf
+	buf.WriteInt32(28)
+	buf.WriteInt32(1) // dimensions
+	buf.WriteInt32(0) // flags
+	buf.WriteInt32(int32(Int4Oid))
+	buf.WriteInt32(2) // length of the dimension
+	buf.WriteInt32(0) // lower bound of the dimension
+	encodeInt4(&buf, 7)
+	encodeInt4(&buf, 8)
+	var a []int32
+	if err := Int4ArrayScanner(&a).(ValueScanner).ScanValue(binaryValue(Int4ArrayOid, buf)); err != nil || len(a) != 2 || a[1] != 8 {
+		t.Fatal(err, a)
+	}
+}
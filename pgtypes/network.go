@@ -0,0 +1,558 @@
+package pgtypes
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/wdamron/pgx"
+)
+
+// address families of binary inet and cidr values
+const (
+	pgAFInet  = 2
+	pgAFInet6 = 3
+)
+
+// encode addr, a 4- or 16-byte address, with a prefix length of bits as a
+// binary inet or cidr value (the host bits of cidr values are cleared)
+func encodeInet(w ValueWriter, addr []byte, bits int, cidr bool) error {
+	family := byte(pgAFInet)
+	switch len(addr) {
+	case net.IPv4len:
+	case net.IPv6len:
+		family = pgAFInet6
+	default:
+		return fmt.Errorf("Cannot encode an IP address of length %d", len(addr))
+	}
+	if bits < 0 || bits > 8*len(addr) {
+		return fmt.Errorf("Cannot encode an IP address with a prefix length of %d", bits)
+	}
+	isCidr := byte(0)
+	if cidr {
+		isCidr = 1
+		addr = net.IP(addr).Mask(net.CIDRMask(bits, 8*len(addr)))
+	}
+	w.WriteInt32(int32(4 + len(addr)))
+	w.WriteBytes([]byte{family, byte(bits), isCidr, byte(len(addr))})
+	w.WriteBytes(addr)
+	return nil
+}
+
+// encode v as a binary inet or cidr value, with a prefix covering all of v
+func encodeInetIP(w ValueWriter, v net.IP, cidr bool) error {
+	addr := v.To4()
+	if addr == nil {
+		if addr = v.To16(); addr == nil {
+			return fmt.Errorf("Cannot encode an invalid IP address")
+		}
+	}
+	return encodeInet(w, addr, 8*len(addr), cidr)
+}
+
+func encodeInetIPNet(w ValueWriter, v *net.IPNet, cidr bool) error {
+	bits, size := v.Mask.Size()
+	addr := v.IP.To4()
+	if addr == nil || size == 8*net.IPv6len {
+		addr = v.IP.To16()
+	}
+	if addr == nil || size != 8*len(addr) {
+		return fmt.Errorf("Cannot encode an IP network with address %v and mask %v", v.IP, v.Mask)
+	}
+	return encodeInet(w, addr, bits, cidr)
+}
+
+func encodeInetAddr(w ValueWriter, v netip.Addr, cidr bool) error {
+	if !v.IsValid() {
+		return fmt.Errorf("Cannot encode an invalid IP address")
+	}
+	return encodeInet(w, v.AsSlice(), v.BitLen(), cidr)
+}
+
+func encodeInetPrefix(w ValueWriter, v netip.Prefix, cidr bool) error {
+	if !v.IsValid() {
+		return fmt.Errorf("Cannot encode an invalid IP prefix")
+	}
+	return encodeInet(w, v.Addr().AsSlice(), v.Bits(), cidr)
+}
+
+// decode a binary inet or cidr value of the given size, following its length
+// prefix, returning the address and its prefix length
+func decodeInetBinary(vr ValueReader, size int32) ([]byte, int) {
+	if size != 4+net.IPv4len && size != 4+net.IPv6len {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for an inet: %d", size)))
+		return nil, 0
+	}
+	family, bits := vr.ReadUint8(), int(vr.ReadUint8())
+	vr.ReadUint8() // is_cidr
+	n := int32(vr.ReadUint8())
+	if n != size-4 || (family == pgAFInet) != (n == net.IPv4len) || bits > 8*int(n) {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid inet with family %d, prefix length %d and address length %d", family, bits, n)))
+		return nil, 0
+	}
+	return vr.ReadBytes(n), bits
+}
+
+// decode a binary inet or cidr value with the given oid
+func decodeInet(vr ValueReader, oid pgx.Oid, into string) ([]byte, int) {
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into " + into))
+		return nil, 0
+	}
+
+	if vr.Type().DataType != oid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into %s", vr.Type().DataType, into)))
+		return nil, 0
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return nil, 0
+	}
+
+	return decodeInetBinary(vr, vr.Len())
+}
+
+func inetIP(addr []byte) net.IP {
+	return append(net.IP(nil), addr...)
+}
+
+func inetIPNet(addr []byte, bits int) net.IPNet {
+	return net.IPNet{IP: inetIP(addr), Mask: net.CIDRMask(bits, 8*len(addr))}
+}
+
+func inetAddr(addr []byte) netip.Addr {
+	a, _ := netip.AddrFromSlice(addr)
+	return a
+}
+
+func inetPrefix(addr []byte, bits int) netip.Prefix {
+	return netip.PrefixFrom(inetAddr(addr), bits)
+}
+
+type inetEncoder struct {
+	name string
+	oid  pgx.Oid
+	enc  func(w ValueWriter, cidr bool) error
+}
+
+func (e *inetEncoder) FormatCode() int16 { return 1 }
+
+func (e *inetEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *inetEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.oid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", e.name, oid)
+	}
+
+	return e.enc(w, oid == CidrOid)
+}
+
+type inetScanner struct {
+	oid  pgx.Oid
+	into string
+	// assign the decoded address and prefix length
+	assign func(addr []byte, bits int)
+}
+
+func (s *inetScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s *inetScanner) ScanValue(vr ValueReader) error {
+	addr, bits := decodeInet(vr, s.oid, s.into)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	s.assign(addr, bits)
+	return nil
+}
+
+// InetEncoderIP returns an encoder which writes v as an inet value, with a
+// prefix length covering all of v.
+func InetEncoderIP(v net.IP) pgx.Encoder {
+	return &inetEncoder{"InetEncoder", InetOid, func(w ValueWriter, cidr bool) error { return encodeInetIP(w, v, cidr) }}
+}
+
+// InetEncoderIPNet returns an encoder which writes the address and mask of v as
+// an inet value.
+func InetEncoderIPNet(v *net.IPNet) pgx.Encoder {
+	return &inetEncoder{"InetEncoder", InetOid, func(w ValueWriter, cidr bool) error { return encodeInetIPNet(w, v, cidr) }}
+}
+
+// InetEncoderAddr returns an encoder which writes v as an inet value, with a
+// prefix length covering all of v.
+func InetEncoderAddr(v netip.Addr) pgx.Encoder {
+	return &inetEncoder{"InetEncoder", InetOid, func(w ValueWriter, cidr bool) error { return encodeInetAddr(w, v, cidr) }}
+}
+
+// InetEncoderPrefix returns an encoder which writes v as an inet value.
+func InetEncoderPrefix(v netip.Prefix) pgx.Encoder {
+	return &inetEncoder{"InetEncoder", InetOid, func(w ValueWriter, cidr bool) error { return encodeInetPrefix(w, v, cidr) }}
+}
+
+// InetScannerIP returns a scanner which decodes the address of inet values into
+// v, discarding the prefix length.
+func InetScannerIP(v *net.IP) pgx.Scanner {
+	return &inetScanner{InetOid, "net.IP", func(addr []byte, bits int) { *v = inetIP(addr) }}
+}
+
+// InetScannerIPNet returns a scanner which decodes inet values into v.
+func InetScannerIPNet(v *net.IPNet) pgx.Scanner {
+	return &inetScanner{InetOid, "net.IPNet", func(addr []byte, bits int) { *v = inetIPNet(addr, bits) }}
+}
+
+// InetScannerAddr returns a scanner which decodes the address of inet values
+// into v, discarding the prefix length.
+func InetScannerAddr(v *netip.Addr) pgx.Scanner {
+	return &inetScanner{InetOid, "netip.Addr", func(addr []byte, bits int) { *v = inetAddr(addr) }}
+}
+
+// InetScannerPrefix returns a scanner which decodes inet values into v. The
+// host bits of the address are kept.
+func InetScannerPrefix(v *netip.Prefix) pgx.Scanner {
+	return &inetScanner{InetOid, "netip.Prefix", func(addr []byte, bits int) { *v = inetPrefix(addr, bits) }}
+}
+
+// CidrEncoderIP returns an encoder which writes v as a cidr value, with a
+// prefix length covering all of v.
+func CidrEncoderIP(v net.IP) pgx.Encoder {
+	return &inetEncoder{"CidrEncoder", CidrOid, func(w ValueWriter, cidr bool) error { return encodeInetIP(w, v, cidr) }}
+}
+
+// CidrEncoderIPNet returns an encoder which writes v as a cidr value, clearing
+// the host bits of its address.
+func CidrEncoderIPNet(v *net.IPNet) pgx.Encoder {
+	return &inetEncoder{"CidrEncoder", CidrOid, func(w ValueWriter, cidr bool) error { return encodeInetIPNet(w, v, cidr) }}
+}
+
+// CidrEncoderAddr returns an encoder which writes v as a cidr value, with a
+// prefix length covering all of v.
+func CidrEncoderAddr(v netip.Addr) pgx.Encoder {
+	return &inetEncoder{"CidrEncoder", CidrOid, func(w ValueWriter, cidr bool) error { return encodeInetAddr(w, v, cidr) }}
+}
+
+// CidrEncoderPrefix returns an encoder which writes v as a cidr value, clearing
+// the host bits of its address.
+func CidrEncoderPrefix(v netip.Prefix) pgx.Encoder {
+	return &inetEncoder{"CidrEncoder", CidrOid, func(w ValueWriter, cidr bool) error { return encodeInetPrefix(w, v, cidr) }}
+}
+
+// CidrScannerIP returns a scanner which decodes the address of cidr values into
+// v, discarding the prefix length.
+func CidrScannerIP(v *net.IP) pgx.Scanner {
+	return &inetScanner{CidrOid, "net.IP", func(addr []byte, bits int) { *v = inetIP(addr) }}
+}
+
+// CidrScannerIPNet returns a scanner which decodes cidr values into v.
+func CidrScannerIPNet(v *net.IPNet) pgx.Scanner {
+	return &inetScanner{CidrOid, "net.IPNet", func(addr []byte, bits int) { *v = inetIPNet(addr, bits) }}
+}
+
+// CidrScannerAddr returns a scanner which decodes the address of cidr values
+// into v, discarding the prefix length.
+func CidrScannerAddr(v *netip.Addr) pgx.Scanner {
+	return &inetScanner{CidrOid, "netip.Addr", func(addr []byte, bits int) { *v = inetAddr(addr) }}
+}
+
+// CidrScannerPrefix returns a scanner which decodes cidr values into v.
+func CidrScannerPrefix(v *netip.Prefix) pgx.Scanner {
+	return &inetScanner{CidrOid, "netip.Prefix", func(addr []byte, bits int) { *v = inetPrefix(addr, bits) }}
+}
+
+// encode v as a binary macaddr (6 bytes) or macaddr8 (8 bytes) value. 6-byte
+// addresses are written to macaddr8 values in the modified EUI-64 format used
+// by PostgreSQL.
+func encodeMacaddr(w ValueWriter, v net.HardwareAddr, size int) error {
+	if size == 8 && len(v) == 6 {
+		v = net.HardwareAddr{v[0], v[1], v[2], 0xff, 0xfe, v[3], v[4], v[5]}
+	}
+	if len(v) != size {
+		return fmt.Errorf("Cannot encode a hardware address of length %d into a %d-byte address", len(v), size)
+	}
+	w.WriteInt32(int32(size))
+	w.WriteBytes(v)
+	return nil
+}
+
+// decode a binary macaddr or macaddr8 value of the given size, following its
+// length prefix
+func decodeMacaddrBinary(vr ValueReader, size, want int32) net.HardwareAddr {
+	if size != want {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a %d-byte hardware address: %d", want, size)))
+		return nil
+	}
+	return net.HardwareAddr(append([]byte(nil), vr.ReadBytes(size)...))
+}
+
+type macaddrEncoder struct {
+	name string
+	oid  pgx.Oid
+	size int
+	v    net.HardwareAddr
+}
+
+// MacaddrEncoder returns an encoder which writes v, a 6-byte address, as a
+// macaddr value.
+func MacaddrEncoder(v net.HardwareAddr) pgx.Encoder {
+	return &macaddrEncoder{"MacaddrEncoder", MacaddrOid, 6, v}
+}
+
+// Macaddr8Encoder returns an encoder which writes v, a 6- or 8-byte address, as
+// a macaddr8 value.
+func Macaddr8Encoder(v net.HardwareAddr) pgx.Encoder {
+	return &macaddrEncoder{"Macaddr8Encoder", Macaddr8Oid, 8, v}
+}
+
+func (e *macaddrEncoder) FormatCode() int16 { return 1 }
+
+func (e *macaddrEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *macaddrEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.oid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", e.name, oid)
+	}
+
+	return encodeMacaddr(w, e.v, e.size)
+}
+
+type macaddrScanner struct {
+	oid  pgx.Oid
+	size int32
+	v    *net.HardwareAddr
+}
+
+// MacaddrScanner returns a scanner which decodes macaddr values into v.
+func MacaddrScanner(v *net.HardwareAddr) pgx.Scanner {
+	return &macaddrScanner{MacaddrOid, 6, v}
+}
+
+// Macaddr8Scanner returns a scanner which decodes macaddr8 values into v.
+func Macaddr8Scanner(v *net.HardwareAddr) pgx.Scanner {
+	return &macaddrScanner{Macaddr8Oid, 8, v}
+}
+
+func (s *macaddrScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s *macaddrScanner) ScanValue(vr ValueReader) error {
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into net.HardwareAddr"))
+		return vr.Err()
+	}
+
+	if vr.Type().DataType != s.oid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into net.HardwareAddr", vr.Type().DataType)))
+		return vr.Err()
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return vr.Err()
+	}
+
+	addr := decodeMacaddrBinary(vr, vr.Len(), s.size)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	*s.v = addr
+	return nil
+}
+
+// CidrArrayEncoderIP returns an encoder which writes v as a cidr[] value, with
+// a NULL element for each nil element of v.
+func CidrArrayEncoderIP(v []net.IP) pgx.Encoder {
+	return inetArrayEncoderIP("CidrArrayEncoder", CidrArrayOid, CidrOid, v)
+}
+
+// CidrArrayEncoderIPNet returns an encoder which writes v as a cidr[] value,
+// with a NULL element for each nil element of v.
+func CidrArrayEncoderIPNet(v []*net.IPNet) pgx.Encoder {
+	return inetArrayEncoderIPNet("CidrArrayEncoder", CidrArrayOid, CidrOid, v)
+}
+
+// CidrArrayEncoderAddr returns an encoder which writes v as a cidr[] value,
+// with a NULL element for each invalid (zero) element of v.
+func CidrArrayEncoderAddr(v []netip.Addr) pgx.Encoder {
+	return inetArrayEncoderAddr("CidrArrayEncoder", CidrArrayOid, CidrOid, v)
+}
+
+// CidrArrayEncoderPrefix returns an encoder which writes v as a cidr[] value,
+// with a NULL element for each invalid (zero) element of v.
+func CidrArrayEncoderPrefix(v []netip.Prefix) pgx.Encoder {
+	return inetArrayEncoderPrefix("CidrArrayEncoder", CidrArrayOid, CidrOid, v)
+}
+
+// CidrArrayScannerIP returns a scanner which decodes the addresses of cidr[]
+// values into v, with a nil element for each NULL element.
+func CidrArrayScannerIP(v *[]net.IP) pgx.Scanner {
+	return inetArrayScannerIP(CidrArrayOid, v)
+}
+
+// CidrArrayScannerIPNet returns a scanner which decodes cidr[] values into v,
+// with a nil element for each NULL element.
+func CidrArrayScannerIPNet(v *[]*net.IPNet) pgx.Scanner {
+	return inetArrayScannerIPNet(CidrArrayOid, v)
+}
+
+// CidrArrayScannerAddr returns a scanner which decodes the addresses of cidr[]
+// values into v, with an invalid (zero) element for each NULL element.
+func CidrArrayScannerAddr(v *[]netip.Addr) pgx.Scanner {
+	return inetArrayScannerAddr(CidrArrayOid, v)
+}
+
+// CidrArrayScannerPrefix returns a scanner which decodes cidr[] values into v,
+// with an invalid (zero) element for each NULL element.
+func CidrArrayScannerPrefix(v *[]netip.Prefix) pgx.Scanner {
+	return inetArrayScannerPrefix(CidrArrayOid, v)
+}
+
+func inetArrayEncoderIP(name string, arrayOid, elemOid pgx.Oid, v []net.IP) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: name, arrayOid: arrayOid, elemOid: elemOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeInetIP(w, v[i], elemOid == CidrOid) },
+	}
+}
+
+func inetArrayEncoderIPNet(name string, arrayOid, elemOid pgx.Oid, v []*net.IPNet) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: name, arrayOid: arrayOid, elemOid: elemOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeInetIPNet(w, v[i], elemOid == CidrOid) },
+	}
+}
+
+func inetArrayEncoderAddr(name string, arrayOid, elemOid pgx.Oid, v []netip.Addr) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: name, arrayOid: arrayOid, elemOid: elemOid, n: len(v),
+		null: func(i int) bool { return !v[i].IsValid() },
+		enc:  func(w ValueWriter, i int) error { return encodeInetAddr(w, v[i], elemOid == CidrOid) },
+	}
+}
+
+func inetArrayEncoderPrefix(name string, arrayOid, elemOid pgx.Oid, v []netip.Prefix) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: name, arrayOid: arrayOid, elemOid: elemOid, n: len(v),
+		null: func(i int) bool { return !v[i].IsValid() },
+		enc:  func(w ValueWriter, i int) error { return encodeInetPrefix(w, v[i], elemOid == CidrOid) },
+	}
+}
+
+func inetArrayScannerIP(arrayOid pgx.Oid, v *[]net.IP) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: arrayOid, into: "[]net.IP",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]net.IP, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if addr, _ := decodeInetBinary(vr, size); vr.Err() == nil {
+				(*v)[i] = inetIP(addr)
+			}
+		},
+	}
+}
+
+func inetArrayScannerIPNet(arrayOid pgx.Oid, v *[]*net.IPNet) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: arrayOid, into: "[]*net.IPNet",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*net.IPNet, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if addr, bits := decodeInetBinary(vr, size); vr.Err() == nil {
+				x := inetIPNet(addr, bits)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+func inetArrayScannerAddr(arrayOid pgx.Oid, v *[]netip.Addr) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: arrayOid, into: "[]netip.Addr",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]netip.Addr, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if addr, _ := decodeInetBinary(vr, size); vr.Err() == nil {
+				(*v)[i] = inetAddr(addr)
+			}
+		},
+	}
+}
+
+func inetArrayScannerPrefix(arrayOid pgx.Oid, v *[]netip.Prefix) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: arrayOid, into: "[]netip.Prefix",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]netip.Prefix, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if addr, bits := decodeInetBinary(vr, size); vr.Err() == nil {
+				(*v)[i] = inetPrefix(addr, bits)
+			}
+		},
+	}
+}
+
+// MacaddrArrayEncoder returns an encoder which writes v as a macaddr[] value,
+// with a NULL element for each nil element of v.
+func MacaddrArrayEncoder(v []net.HardwareAddr) pgx.Encoder {
+	return macaddrArrayEncoder("MacaddrArrayEncoder", MacaddrArrayOid, MacaddrOid, 6, v)
+}
+
+// Macaddr8ArrayEncoder returns an encoder which writes v as a macaddr8[] value,
+// with a NULL element for each nil element of v.
+func Macaddr8ArrayEncoder(v []net.HardwareAddr) pgx.Encoder {
+	return macaddrArrayEncoder("Macaddr8ArrayEncoder", Macaddr8ArrayOid, Macaddr8Oid, 8, v)
+}
+
+// MacaddrArrayScanner returns a scanner which decodes macaddr[] values into v,
+// with a nil element for each NULL element.
+func MacaddrArrayScanner(v *[]net.HardwareAddr) pgx.Scanner {
+	return macaddrArrayScanner(MacaddrArrayOid, 6, v)
+}
+
+// Macaddr8ArrayScanner returns a scanner which decodes macaddr8[] values into
+// v, with a nil element for each NULL element.
+func Macaddr8ArrayScanner(v *[]net.HardwareAddr) pgx.Scanner {
+	return macaddrArrayScanner(Macaddr8ArrayOid, 8, v)
+}
+
+func macaddrArrayEncoder(name string, arrayOid, elemOid pgx.Oid, size int, v []net.HardwareAddr) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: name, arrayOid: arrayOid, elemOid: elemOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeMacaddr(w, v[i], size) },
+	}
+}
+
+func macaddrArrayScanner(arrayOid pgx.Oid, size int32, v *[]net.HardwareAddr) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: arrayOid, into: "[]net.HardwareAddr",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]net.HardwareAddr, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, elSize int32) {
+			if addr := decodeMacaddrBinary(vr, elSize, size); vr.Err() == nil {
+				(*v)[i] = addr
+			}
+		},
+	}
+}
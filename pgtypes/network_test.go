@@ -0,0 +1,95 @@
+package pgtypes
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestNetwork(t *testing.T) {
+	_, network, _ := net.ParseCIDR("10.1.0.0/16")
+	host := &net.IPNet{IP: net.ParseIP("192.168.1.7"), Mask: net.CIDRMask(24, 32)}
+	mac := net.HardwareAddr{1, 2, 3, 4, 5, 6}
+	oids := []pgx.Oid{InetOid, CidrOid, InetOid, CidrOid, InetOid, MacaddrOid, Macaddr8Oid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		InetEncoderIP(net.ParseIP("127.0.0.1")),
+		CidrEncoderIPNet(network),
+		InetEncoderIPNet(host),
+		CidrEncoderPrefix(netip.MustParsePrefix("2001:db8::1/64")),
+		InetEncoderAddr(netip.MustParseAddr("::1")),
+		MacaddrEncoder(mac),
+		Macaddr8Encoder(mac),
+	})
+	// IPv4 addresses are decoded as 4-byte addresses
+	var ip net.IP
+	if err := scanField(t, cr, InetOid, InetScannerIP(&ip)); err != nil || !ip.Equal(net.ParseIP("127.0.0.1")) || len(ip) != net.IPv4len {
+		t.Fatal(err, ip)
+	}
+	var n net.IPNet
+	if err := scanField(t, cr, CidrOid, CidrScannerIPNet(&n)); err != nil || n.String() != "10.1.0.0/16" {
+		t.Fatal(err, n)
+	}
+	// inet values keep the host bits, while cidr values do not
+	var p1 netip.Prefix
+	if err := scanField(t, cr, InetOid, InetScannerPrefix(&p1)); err != nil || p1.String() != "192.168.1.7/24" {
+		t.Fatal(err, p1)
+	}
+	var p2 netip.Prefix
+	if err := scanField(t, cr, CidrOid, CidrScannerPrefix(&p2)); err != nil || p2.String() != "2001:db8::/64" {
+		t.Fatal(err, p2)
+	}
+	var a netip.Addr
+	if err := scanField(t, cr, InetOid, InetScannerAddr(&a)); err != nil || a.String() != "::1" {
+		t.Fatal(err, a)
+	}
+	var m6, m8 net.HardwareAddr
+	if err := scanField(t, cr, MacaddrOid, MacaddrScanner(&m6)); err != nil || m6.String() != "01:02:03:04:05:06" {
+		t.Fatal(err, m6)
+	}
+	// 6-byte addresses are converted to 8-byte EUI-64 addresses for macaddr8
+	if err := scanField(t, cr, Macaddr8Oid, Macaddr8Scanner(&m8)); err != nil || m8.String() != "01:02:03:ff:fe:04:05:06" {
+		t.Fatal(err, m8)
+	}
+}
+
+func TestNetworkEncoding(t *testing.T) {
+	var buf copyBuf
+	if err := CidrEncoderIP(net.ParseIP("10.0.0.1")).(ValueEncoder).EncodeValue(&buf, CidrOid); err != nil {
+		t.Fatal(err)
+	}
+	// family, bits, is_cidr, address length, address
+	if want := []byte{0, 0, 0, 8, 2, 32, 1, 4, 10, 0, 0, 1}; string(buf) != string(want) {
+		t.Fatalf("encoded as %x; want %x", []byte(buf), want)
+	}
+	if err := InetEncoderIP(nil).(ValueEncoder).EncodeValue(&copyBuf{}, InetOid); err == nil {
+		t.Error("expected an error encoding an invalid address")
+	}
+	if err := MacaddrEncoder(net.HardwareAddr{1, 2, 3, 4}).(ValueEncoder).EncodeValue(&copyBuf{}, MacaddrOid); err == nil {
+		t.Error("expected an error encoding a 4-byte macaddr")
+	}
+}
+
+func TestNetworkArrays(t *testing.T) {
+	host := &net.IPNet{IP: net.ParseIP("192.168.1.7"), Mask: net.CIDRMask(24, 32)}
+	mac := net.HardwareAddr{1, 2, 3, 4, 5, 6}
+	cr := copyRow(t, []pgx.Oid{InetArrayOid, MacaddrArrayOid, CidrArrayOid}, []pgx.Encoder{
+		InetArrayEncoderAddr([]netip.Addr{netip.MustParseAddr("1.2.3.4"), {}}),
+		MacaddrArrayEncoder([]net.HardwareAddr{nil, mac}),
+		CidrArrayEncoderIPNet([]*net.IPNet{host, nil}),
+	})
+	// invalid and nil addresses are encoded as NULL elements
+	var as []netip.Addr
+	if err := scanField(t, cr, InetArrayOid, InetArrayScannerAddr(&as)); err != nil || len(as) != 2 || as[0].String() != "1.2.3.4" || as[1].IsValid() {
+		t.Fatal(err, as)
+	}
+	var ms []net.HardwareAddr
+	if err := scanField(t, cr, MacaddrArrayOid, MacaddrArrayScanner(&ms)); err != nil || len(ms) != 2 || ms[0] != nil || ms[1].String() != mac.String() {
+		t.Fatal(err, ms)
+	}
+	var ns []*net.IPNet
+	if err := scanField(t, cr, CidrArrayOid, CidrArrayScannerIPNet(&ns)); err != nil || len(ns) != 2 || ns[0].String() != "192.168.1.0/24" || ns[1] != nil {
+		t.Fatal(err, ns)
+	}
+}
@@ -0,0 +1,721 @@
+package pgtypes
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+// The encoders and scanners within this file support one-dimensional arrays
+// which may contain NULL elements. Elements of []*T slices are NULL if nil, and
+// elements of []pgx.NullT slices are NULL if not valid.
+
+type nullArrayEncoder struct {
+	name              string
+	arrayOid, elemOid pgx.Oid
+	n                 int
+	null              func(i int) bool
+	enc               func(w ValueWriter, i int) error
+}
+
+func (e *nullArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *nullArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *nullArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.arrayOid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", e.name, oid)
+	}
+
+	return encodeArrayNulls(w, e.elemOid, e.n, e.null, e.enc)
+}
+
+type nullArrayScanner struct {
+	arrayOid pgx.Oid
+	into     string
+	// allocate a slice of n elements, or clear the slice if n is -1
+	alloc func(n int)
+	// decode the non-NULL element at index i, which has the given size
+	decode func(vr ValueReader, i int, size int32)
+}
+
+func (s *nullArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s *nullArrayScanner) ScanValue(vr ValueReader) error {
+	if vr.Len() == -1 {
+		s.alloc(-1)
+		return nil
+	}
+
+	numElems := decodeArrayHeader(vr, s.arrayOid, s.into)
+	if vr.Err() != nil {
+		s.alloc(-1)
+		return vr.Err()
+	}
+
+	s.alloc(numElems)
+	for i := 0; i < numElems; i++ {
+		size := vr.ReadInt32()
+		if size != -1 {
+			s.decode(vr, i, size)
+		}
+		if vr.Err() != nil {
+			s.alloc(-1)
+			return vr.Err()
+		}
+	}
+	return nil
+}
+
+// check the size of a non-NULL array element, which must be want if non-zero
+func checkArrayElementSize(vr ValueReader, size, want int32, elem string) bool {
+	if size < 0 || (want != 0 && size != want) {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a %s element: %d", elem, size)))
+		return false
+	}
+	return true
+}
+
+// decode a binary timestamp, following its length prefix, as a wall clock time
+// in the location set by SetLocation
+func decodeTimestampBinary(vr ValueReader) time.Time {
+	return timestampOf(vr.ReadInt64(), defaultLocation()).SentinelTime()
+}
+
+// decode a binary timestamptz, following its length prefix
+func decodeTimestampTzBinary(vr ValueReader) time.Time {
+	return timestampOf(vr.ReadInt64(), nil).SentinelTime()
+}
+
+// BoolArrayEncoderPtr returns an encoder which writes v as a bool[] value, with a
+// NULL element for each nil element of v.
+func BoolArrayEncoderPtr(v []*bool) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "BoolArrayEncoder", arrayOid: BoolArrayOid, elemOid: BoolOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeBool(w, *v[i]) },
+	}
+}
+
+// BoolArrayEncoderNull returns an encoder which writes v as a bool[] value, with
+// a NULL element for each invalid element of v.
+func BoolArrayEncoderNull(v []pgx.NullBool) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "BoolArrayEncoder", arrayOid: BoolArrayOid, elemOid: BoolOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeBool(w, v[i].Bool) },
+	}
+}
+
+// BoolArrayScannerPtr returns a scanner which decodes bool[] values into v, with
+// a nil element for each NULL element.
+func BoolArrayScannerPtr(v *[]*bool) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: BoolArrayOid, into: "[]*bool",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*bool, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 1, "bool") {
+				x := vr.ReadUint8() == 1
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// BoolArrayScannerNull returns a scanner which decodes bool[] values into v, with
+// an invalid element for each NULL element.
+func BoolArrayScannerNull(v *[]pgx.NullBool) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: BoolArrayOid, into: "[]pgx.NullBool",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullBool, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 1, "bool") {
+				(*v)[i] = pgx.NullBool{Bool: vr.ReadUint8() == 1, Valid: true}
+			}
+		},
+	}
+}
+
+// Int2ArrayEncoderPtr returns an encoder which writes v as a int2[] value, with a
+// NULL element for each nil element of v.
+func Int2ArrayEncoderPtr(v []*int16) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int2ArrayEncoder", arrayOid: Int2ArrayOid, elemOid: Int2Oid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeInt2(w, *v[i]) },
+	}
+}
+
+// Int2ArrayEncoderNull returns an encoder which writes v as a int2[] value, with
+// a NULL element for each invalid element of v.
+func Int2ArrayEncoderNull(v []pgx.NullInt16) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int2ArrayEncoder", arrayOid: Int2ArrayOid, elemOid: Int2Oid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeInt2(w, v[i].Int16) },
+	}
+}
+
+// Int2ArrayScannerPtr returns a scanner which decodes int2[] values into v, with
+// a nil element for each NULL element.
+func Int2ArrayScannerPtr(v *[]*int16) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int2ArrayOid, into: "[]*int16",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*int16, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 2, "int2") {
+				x := vr.ReadInt16()
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// Int2ArrayScannerNull returns a scanner which decodes int2[] values into v, with
+// an invalid element for each NULL element.
+func Int2ArrayScannerNull(v *[]pgx.NullInt16) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int2ArrayOid, into: "[]pgx.NullInt16",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullInt16, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 2, "int2") {
+				(*v)[i] = pgx.NullInt16{Int16: vr.ReadInt16(), Valid: true}
+			}
+		},
+	}
+}
+
+// Int4ArrayEncoderPtr returns an encoder which writes v as a int4[] value, with a
+// NULL element for each nil element of v.
+func Int4ArrayEncoderPtr(v []*int32) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int4ArrayEncoder", arrayOid: Int4ArrayOid, elemOid: Int4Oid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeInt4(w, *v[i]) },
+	}
+}
+
+// Int4ArrayEncoderNull returns an encoder which writes v as a int4[] value, with
+// a NULL element for each invalid element of v.
+func Int4ArrayEncoderNull(v []pgx.NullInt32) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int4ArrayEncoder", arrayOid: Int4ArrayOid, elemOid: Int4Oid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeInt4(w, v[i].Int32) },
+	}
+}
+
+// Int4ArrayScannerPtr returns a scanner which decodes int4[] values into v, with
+// a nil element for each NULL element.
+func Int4ArrayScannerPtr(v *[]*int32) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int4ArrayOid, into: "[]*int32",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*int32, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "int4") {
+				x := vr.ReadInt32()
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// Int4ArrayScannerNull returns a scanner which decodes int4[] values into v, with
+// an invalid element for each NULL element.
+func Int4ArrayScannerNull(v *[]pgx.NullInt32) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int4ArrayOid, into: "[]pgx.NullInt32",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullInt32, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "int4") {
+				(*v)[i] = pgx.NullInt32{Int32: vr.ReadInt32(), Valid: true}
+			}
+		},
+	}
+}
+
+// Int8ArrayEncoderPtr returns an encoder which writes v as a int8[] value, with a
+// NULL element for each nil element of v.
+func Int8ArrayEncoderPtr(v []*int64) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int8ArrayEncoder", arrayOid: Int8ArrayOid, elemOid: Int8Oid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeInt8(w, *v[i]) },
+	}
+}
+
+// Int8ArrayEncoderNull returns an encoder which writes v as a int8[] value, with
+// a NULL element for each invalid element of v.
+func Int8ArrayEncoderNull(v []pgx.NullInt64) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Int8ArrayEncoder", arrayOid: Int8ArrayOid, elemOid: Int8Oid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeInt8(w, v[i].Int64) },
+	}
+}
+
+// Int8ArrayScannerPtr returns a scanner which decodes int8[] values into v, with
+// a nil element for each NULL element.
+func Int8ArrayScannerPtr(v *[]*int64) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int8ArrayOid, into: "[]*int64",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*int64, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "int8") {
+				x := vr.ReadInt64()
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// Int8ArrayScannerNull returns a scanner which decodes int8[] values into v, with
+// an invalid element for each NULL element.
+func Int8ArrayScannerNull(v *[]pgx.NullInt64) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Int8ArrayOid, into: "[]pgx.NullInt64",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullInt64, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "int8") {
+				(*v)[i] = pgx.NullInt64{Int64: vr.ReadInt64(), Valid: true}
+			}
+		},
+	}
+}
+
+// Float4ArrayEncoderPtr returns an encoder which writes v as a float4[] value, with a
+// NULL element for each nil element of v.
+func Float4ArrayEncoderPtr(v []*float32) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Float4ArrayEncoder", arrayOid: Float4ArrayOid, elemOid: Float4Oid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeFloat4(w, *v[i]) },
+	}
+}
+
+// Float4ArrayEncoderNull returns an encoder which writes v as a float4[] value, with
+// a NULL element for each invalid element of v.
+func Float4ArrayEncoderNull(v []pgx.NullFloat32) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Float4ArrayEncoder", arrayOid: Float4ArrayOid, elemOid: Float4Oid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeFloat4(w, v[i].Float32) },
+	}
+}
+
+// Float4ArrayScannerPtr returns a scanner which decodes float4[] values into v, with
+// a nil element for each NULL element.
+func Float4ArrayScannerPtr(v *[]*float32) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Float4ArrayOid, into: "[]*float32",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*float32, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "float4") {
+				x := math.Float32frombits(uint32(vr.ReadInt32()))
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// Float4ArrayScannerNull returns a scanner which decodes float4[] values into v, with
+// an invalid element for each NULL element.
+func Float4ArrayScannerNull(v *[]pgx.NullFloat32) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Float4ArrayOid, into: "[]pgx.NullFloat32",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullFloat32, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "float4") {
+				(*v)[i] = pgx.NullFloat32{Float32: math.Float32frombits(uint32(vr.ReadInt32())), Valid: true}
+			}
+		},
+	}
+}
+
+// Float8ArrayEncoderPtr returns an encoder which writes v as a float8[] value, with a
+// NULL element for each nil element of v.
+func Float8ArrayEncoderPtr(v []*float64) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Float8ArrayEncoder", arrayOid: Float8ArrayOid, elemOid: Float8Oid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeFloat8(w, *v[i]) },
+	}
+}
+
+// Float8ArrayEncoderNull returns an encoder which writes v as a float8[] value, with
+// a NULL element for each invalid element of v.
+func Float8ArrayEncoderNull(v []pgx.NullFloat64) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "Float8ArrayEncoder", arrayOid: Float8ArrayOid, elemOid: Float8Oid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeFloat8(w, v[i].Float64) },
+	}
+}
+
+// Float8ArrayScannerPtr returns a scanner which decodes float8[] values into v, with
+// a nil element for each NULL element.
+func Float8ArrayScannerPtr(v *[]*float64) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Float8ArrayOid, into: "[]*float64",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*float64, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "float8") {
+				x := math.Float64frombits(uint64(vr.ReadInt64()))
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// Float8ArrayScannerNull returns a scanner which decodes float8[] values into v, with
+// an invalid element for each NULL element.
+func Float8ArrayScannerNull(v *[]pgx.NullFloat64) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: Float8ArrayOid, into: "[]pgx.NullFloat64",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullFloat64, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "float8") {
+				(*v)[i] = pgx.NullFloat64{Float64: math.Float64frombits(uint64(vr.ReadInt64())), Valid: true}
+			}
+		},
+	}
+}
+
+// TextArrayEncoderPtr returns an encoder which writes v as a text[] value, with a
+// NULL element for each nil element of v.
+func TextArrayEncoderPtr(v []*string) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TextArrayEncoder", arrayOid: TextArrayOid, elemOid: TextOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeText(w, *v[i]) },
+	}
+}
+
+// TextArrayEncoderNull returns an encoder which writes v as a text[] value, with
+// a NULL element for each invalid element of v.
+func TextArrayEncoderNull(v []pgx.NullString) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TextArrayEncoder", arrayOid: TextArrayOid, elemOid: TextOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeText(w, v[i].String) },
+	}
+}
+
+// TextArrayScannerPtr returns a scanner which decodes text[] values into v, with
+// a nil element for each NULL element.
+func TextArrayScannerPtr(v *[]*string) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TextArrayOid, into: "[]*string",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*string, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 0, "text") {
+				x := vr.ReadString(size)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// TextArrayScannerNull returns a scanner which decodes text[] values into v, with
+// an invalid element for each NULL element.
+func TextArrayScannerNull(v *[]pgx.NullString) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TextArrayOid, into: "[]pgx.NullString",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullString, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 0, "text") {
+				(*v)[i] = pgx.NullString{String: vr.ReadString(size), Valid: true}
+			}
+		},
+	}
+}
+
+// VarcharArrayEncoderPtr returns an encoder which writes v as a varchar[] value, with a
+// NULL element for each nil element of v.
+func VarcharArrayEncoderPtr(v []*string) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "VarcharArrayEncoder", arrayOid: VarcharArrayOid, elemOid: VarcharOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeVarchar(w, *v[i]) },
+	}
+}
+
+// VarcharArrayEncoderNull returns an encoder which writes v as a varchar[] value, with
+// a NULL element for each invalid element of v.
+func VarcharArrayEncoderNull(v []pgx.NullString) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "VarcharArrayEncoder", arrayOid: VarcharArrayOid, elemOid: VarcharOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeVarchar(w, v[i].String) },
+	}
+}
+
+// VarcharArrayScannerPtr returns a scanner which decodes varchar[] values into v, with
+// a nil element for each NULL element.
+func VarcharArrayScannerPtr(v *[]*string) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: VarcharArrayOid, into: "[]*string",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*string, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 0, "varchar") {
+				x := vr.ReadString(size)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// VarcharArrayScannerNull returns a scanner which decodes varchar[] values into v, with
+// an invalid element for each NULL element.
+func VarcharArrayScannerNull(v *[]pgx.NullString) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: VarcharArrayOid, into: "[]pgx.NullString",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullString, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 0, "varchar") {
+				(*v)[i] = pgx.NullString{String: vr.ReadString(size), Valid: true}
+			}
+		},
+	}
+}
+
+// TimestampArrayEncoderPtr returns an encoder which writes v as a timestamp[] value, with a
+// NULL element for each nil element of v.
+func TimestampArrayEncoderPtr(v []*time.Time) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TimestampArrayEncoder", arrayOid: TimestampArrayOid, elemOid: TimestampOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeTimestamp(w, *v[i]) },
+	}
+}
+
+// TimestampArrayEncoderNull returns an encoder which writes v as a timestamp[] value, with
+// a NULL element for each invalid element of v.
+func TimestampArrayEncoderNull(v []pgx.NullTime) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TimestampArrayEncoder", arrayOid: TimestampArrayOid, elemOid: TimestampOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeTimestamp(w, v[i].Time) },
+	}
+}
+
+// TimestampArrayScannerPtr returns a scanner which decodes timestamp[] values into v, with
+// a nil element for each NULL element.
+func TimestampArrayScannerPtr(v *[]*time.Time) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TimestampArrayOid, into: "[]*time.Time",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*time.Time, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "timestamp") {
+				x := decodeTimestampBinary(vr)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// TimestampArrayScannerNull returns a scanner which decodes timestamp[] values into v, with
+// an invalid element for each NULL element.
+func TimestampArrayScannerNull(v *[]pgx.NullTime) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TimestampArrayOid, into: "[]pgx.NullTime",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullTime, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "timestamp") {
+				(*v)[i] = pgx.NullTime{Time: decodeTimestampBinary(vr), Valid: true}
+			}
+		},
+	}
+}
+
+// TimestampTzArrayEncoderPtr returns an encoder which writes v as a timestamptz[] value, with a
+// NULL element for each nil element of v.
+func TimestampTzArrayEncoderPtr(v []*time.Time) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TimestampTzArrayEncoder", arrayOid: TimestampTzArrayOid, elemOid: TimestampTzOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeTimestampTz(w, *v[i]) },
+	}
+}
+
+// TimestampTzArrayEncoderNull returns an encoder which writes v as a timestamptz[] value, with
+// a NULL element for each invalid element of v.
+func TimestampTzArrayEncoderNull(v []pgx.NullTime) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "TimestampTzArrayEncoder", arrayOid: TimestampTzArrayOid, elemOid: TimestampTzOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeTimestampTz(w, v[i].Time) },
+	}
+}
+
+// TimestampTzArrayScannerPtr returns a scanner which decodes timestamptz[] values into v, with
+// a nil element for each NULL element.
+func TimestampTzArrayScannerPtr(v *[]*time.Time) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TimestampTzArrayOid, into: "[]*time.Time",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*time.Time, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "timestamptz") {
+				x := decodeTimestampTzBinary(vr)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// TimestampTzArrayScannerNull returns a scanner which decodes timestamptz[] values into v, with
+// an invalid element for each NULL element.
+func TimestampTzArrayScannerNull(v *[]pgx.NullTime) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: TimestampTzArrayOid, into: "[]pgx.NullTime",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullTime, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 8, "timestamptz") {
+				(*v)[i] = pgx.NullTime{Time: decodeTimestampTzBinary(vr), Valid: true}
+			}
+		},
+	}
+}
+
+// DateArrayEncoderPtr returns an encoder which writes v as a date[] value, with a
+// NULL element for each nil element of v.
+func DateArrayEncoderPtr(v []*time.Time) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "DateArrayEncoder", arrayOid: DateArrayOid, elemOid: DateOid, n: len(v),
+		null: func(i int) bool { return v[i] == nil },
+		enc:  func(w ValueWriter, i int) error { return encodeDate(w, *v[i]) },
+	}
+}
+
+// DateArrayEncoderNull returns an encoder which writes v as a date[] value, with
+// a NULL element for each invalid element of v.
+func DateArrayEncoderNull(v []pgx.NullTime) pgx.Encoder {
+	return &nullArrayEncoder{
+		name: "DateArrayEncoder", arrayOid: DateArrayOid, elemOid: DateOid, n: len(v),
+		null: func(i int) bool { return !v[i].Valid },
+		enc:  func(w ValueWriter, i int) error { return encodeDate(w, v[i].Time) },
+	}
+}
+
+// DateArrayScannerPtr returns a scanner which decodes date[] values into v, with
+// a nil element for each NULL element.
+func DateArrayScannerPtr(v *[]*time.Time) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: DateArrayOid, into: "[]*time.Time",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]*time.Time, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "date") {
+				x := decodeDateBinary(vr)
+				(*v)[i] = &x
+			}
+		},
+	}
+}
+
+// DateArrayScannerNull returns a scanner which decodes date[] values into v, with
+// an invalid element for each NULL element.
+func DateArrayScannerNull(v *[]pgx.NullTime) pgx.Scanner {
+	return &nullArrayScanner{
+		arrayOid: DateArrayOid, into: "[]pgx.NullTime",
+		alloc: func(n int) {
+			if *v = nil; n >= 0 {
+				*v = make([]pgx.NullTime, n)
+			}
+		},
+		decode: func(vr ValueReader, i int, size int32) {
+			if checkArrayElementSize(vr, size, 4, "date") {
+				(*v)[i] = pgx.NullTime{Time: decodeDateBinary(vr), Valid: true}
+			}
+		},
+	}
+}
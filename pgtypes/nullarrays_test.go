@@ -0,0 +1,53 @@
+package pgtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestNullArrays(t *testing.T) {
+	one, x := int32(1), "x"
+	now := time.Date(2020, 1, 2, 3, 4, 5, 6000, time.UTC)
+	oids := []pgx.Oid{Int4ArrayOid, TextArrayOid, TimestampTzArrayOid, Int8ArrayOid, DateArrayOid, Int4ArrayOid, Int4ArrayOid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		Int4ArrayEncoderPtr([]*int32{&one, nil}),
+		TextArrayEncoderPtr([]*string{nil, &x}),
+		TimestampTzArrayEncoderNull([]pgx.NullTime{{Time: now, Valid: true}, {}}),
+		Int8ArrayEncoderNull([]pgx.NullInt64{{}, {Int64: 7, Valid: true}}),
+		DateArrayEncoderPtr([]*time.Time{nil}),
+		Int4ArrayEncoderPtr([]*int32{&one}),
+		Int4ArrayEncoderPtr([]*int32{nil}),
+	})
+
+	var is []*int32
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScannerPtr(&is)); err != nil || len(is) != 2 || *is[0] != 1 || is[1] != nil {
+		t.Fatal(err, is)
+	}
+	var ss []pgx.NullString
+	if err := scanField(t, cr, TextArrayOid, TextArrayScannerNull(&ss)); err != nil || len(ss) != 2 || ss[0].Valid || ss[1] != (pgx.NullString{String: "x", Valid: true}) {
+		t.Fatal(err, ss)
+	}
+	var ts []*time.Time
+	if err := scanField(t, cr, TimestampTzArrayOid, TimestampTzArrayScannerPtr(&ts)); err != nil || len(ts) != 2 || !ts[0].Equal(now) || ts[1] != nil {
+		t.Fatal(err, ts)
+	}
+	var ls []*int64
+	if err := scanField(t, cr, Int8ArrayOid, Int8ArrayScannerPtr(&ls)); err != nil || len(ls) != 2 || ls[0] != nil || *ls[1] != 7 {
+		t.Fatal(err, ls)
+	}
+	var ds []pgx.NullTime
+	if err := scanField(t, cr, DateArrayOid, DateArrayScannerNull(&ds)); err != nil || len(ds) != 1 || ds[0].Valid {
+		t.Fatal(err, ds)
+	}
+	// arrays without NULL elements decode with the scanners for non-null
+	// elements, but arrays with NULL elements do not
+	var plain []int32
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScanner(&plain)); err != nil || len(plain) != 1 || plain[0] != 1 {
+		t.Fatal(err, plain)
+	}
+	if err := scanField(t, cr, Int4ArrayOid, Int4ArrayScanner(&plain)); err == nil {
+		t.Fatal("expected an error scanning a NULL element into a non-null element")
+	}
+}
@@ -0,0 +1,695 @@
+package pgtypes
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/wdamron/pgx"
+)
+
+// Sign words of the binary numeric format:
+const (
+	numericPos  = 0x0000
+	numericNeg  = 0x4000
+	numericNaN  = 0xC000
+	numericPInf = 0xD000
+	numericNInf = 0xF000
+)
+
+// Limits on the digits of numeric values before and after the decimal point
+const (
+	numericMaxIntDigits   = 131072
+	numericMaxFracDigits  = 16383
+	numericDigitsPerGroup = 4
+)
+
+// decimal holds an exact numeric value of unscaled * 10^-scale, or NaN or an
+// infinity if special is non-zero
+type decimal struct {
+	unscaled *big.Int
+	scale    int
+	special  uint16
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (d decimal) String() string {
+	switch d.special {
+	case numericNaN:
+		return "NaN"
+	case numericPInf:
+		return "Infinity"
+	case numericNInf:
+		return "-Infinity"
+	}
+	digits := new(big.Int).Abs(d.unscaled).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.unscaled.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// parse a decimal from s, which may have a sign, a fractional part and an
+// exponent, or may be NaN or (-)Infinity. Trailing zeros of the fractional
+// part are kept within the scale of the decimal.
+func parseDecimal(s string) (decimal, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "nan":
+		return decimal{special: numericNaN}, nil
+	case "infinity", "+infinity", "inf", "+inf":
+		return decimal{special: numericPInf}, nil
+	case "-infinity", "-inf":
+		return decimal{special: numericNInf}, nil
+	}
+	str := strings.TrimSpace(s)
+	exp := 0
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.Atoi(str[i+1:])
+		if err != nil || e > numericMaxIntDigits || e < -numericMaxFracDigits {
+			return decimal{}, fmt.Errorf("Invalid numeric value: %q", s)
+		}
+		exp, str = e, str[:i]
+	}
+	neg := false
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		neg, str = str[0] == '-', str[1:]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return decimal{}, fmt.Errorf("Invalid numeric value: %q", s)
+	}
+	unscaled, _ := new(big.Int).SetString(digits, 10)
+	scale := len(fracPart) - exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	if scale > numericMaxFracDigits || len(digits)-len(fracPart)+exp > numericMaxIntDigits {
+		return decimal{}, fmt.Errorf("Numeric value out of range: %q", s)
+	}
+	if neg {
+		unscaled.Neg(unscaled)
+	}
+	return decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+// convert v to a decimal, if v has a terminating decimal expansion (i.e. if the
+// denominator of v has no prime factors other than 2 and 5)
+func ratDecimal(v *big.Rat) (decimal, error) {
+	den := new(big.Int).Set(v.Denom())
+	twos := int(den.TrailingZeroBits())
+	den.Rsh(den, uint(twos))
+	fives := 0
+	five, rem := big.NewInt(5), new(big.Int)
+	for {
+		q, r := new(big.Int).QuoRem(den, five, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		den, fives = q, fives+1
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return decimal{}, fmt.Errorf("%s cannot be represented exactly as a numeric value", v.RatString())
+	}
+	scale := twos
+	if fives > scale {
+		scale = fives
+	}
+	if scale > numericMaxFracDigits {
+		return decimal{}, fmt.Errorf("%s has more than %d fractional digits", v.RatString(), numericMaxFracDigits)
+	}
+	unscaled := new(big.Int).Mul(v.Num(), pow10(scale))
+	unscaled.Quo(unscaled, v.Denom())
+	return decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+func float64Decimal(v float64) (decimal, error) {
+	switch {
+	case math.IsNaN(v):
+		return decimal{special: numericNaN}, nil
+	case math.IsInf(v, 1):
+		return decimal{special: numericPInf}, nil
+	case math.IsInf(v, -1):
+		return decimal{special: numericNInf}, nil
+	}
+	// the shortest representation which round-trips is exact for the float:
+	return parseDecimal(strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// fit d to the scale of a numeric(precision, scale) column, returning an error
+// if d has more than scale non-zero fractional digits or more than
+// precision-scale integer digits. Zero precision leaves d unconstrained.
+func (d decimal) fit(precision, scale int) (decimal, error) {
+	if precision == 0 || d.special == numericNaN {
+		return d, nil
+	}
+	if d.special != 0 {
+		return d, fmt.Errorf("Numeric value %s is out of range for numeric(%d,%d)", d, precision, scale)
+	}
+	unscaled := new(big.Int).Set(d.unscaled)
+	switch {
+	case d.scale > scale:
+		r := new(big.Int)
+		unscaled.QuoRem(unscaled, pow10(d.scale-scale), r)
+		if r.Sign() != 0 {
+			return d, fmt.Errorf("Numeric value %s has more than %d fractional digits for numeric(%d,%d)", d, scale, precision, scale)
+		}
+	case d.scale < scale:
+		unscaled.Mul(unscaled, pow10(scale-d.scale))
+	}
+	if new(big.Int).Abs(unscaled).Cmp(pow10(precision)) >= 0 {
+		return d, fmt.Errorf("Numeric value %s is out of range for numeric(%d,%d)", d, precision, scale)
+	}
+	return decimal{unscaled: unscaled, scale: scale}, nil
+}
+
+type numericEncoder struct {
+	v   decimal
+	err error
+}
+
+func newNumericEncoder(v decimal, err error, precision, scale int) pgx.Encoder {
+	if err == nil {
+		v, err = v.fit(precision, scale)
+	}
+	return &numericEncoder{v, err}
+}
+
+// NumericEncoderRat returns an encoder which writes v as a numeric value. If
+// precision is non-zero, v must fit within numeric(precision,scale) without
+// rounding. If v has no terminating decimal expansion, encoding will fail.
+func NumericEncoderRat(v *big.Rat, precision, scale int) pgx.Encoder {
+	if v == nil {
+		return NullEncoder(NumericOid, 1)
+	}
+	d, err := ratDecimal(v)
+	return newNumericEncoder(d, err, precision, scale)
+}
+
+// NumericEncoderInt returns an encoder which writes v as a numeric value. If
+// precision is non-zero, v must fit within numeric(precision,scale).
+func NumericEncoderInt(v *big.Int, precision, scale int) pgx.Encoder {
+	if v == nil {
+		return NullEncoder(NumericOid, 1)
+	}
+	return newNumericEncoder(decimal{unscaled: new(big.Int).Set(v)}, nil, precision, scale)
+}
+
+// NumericEncoderString returns an encoder which writes the decimal string v as
+// a numeric value. If precision is non-zero, v must fit within
+// numeric(precision,scale) without rounding.
+func NumericEncoderString(v string, precision, scale int) pgx.Encoder {
+	d, err := parseDecimal(v)
+	return newNumericEncoder(d, err, precision, scale)
+}
+
+// NumericEncoderInt64 returns an encoder which writes v as a numeric value. If
+// precision is non-zero, v must fit within numeric(precision,scale).
+func NumericEncoderInt64(v int64, precision, scale int) pgx.Encoder {
+	return newNumericEncoder(decimal{unscaled: big.NewInt(v)}, nil, precision, scale)
+}
+
+// NumericEncoderFloat64 returns an encoder which writes the shortest decimal
+// representation of v as a numeric value. If precision is non-zero, v must fit
+// within numeric(precision,scale) without rounding.
+func NumericEncoderFloat64(v float64, precision, scale int) pgx.Encoder {
+	d, err := float64Decimal(v)
+	return newNumericEncoder(d, err, precision, scale)
+}
+
+func (e *numericEncoder) FormatCode() int16 { return 1 }
+
+func (e *numericEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *numericEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != NumericOid {
+		return fmt.Errorf("NumericEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	if e.err != nil {
+		return e.err
+	}
+
+	return encodeNumeric(w, e.v)
+}
+
+func encodeNumeric(w ValueWriter, v decimal) error {
+	var groups []uint16
+	weight, sign, dscale := 0, uint16(numericPos), 0
+	if v.special != 0 {
+		sign = v.special
+	} else {
+		dscale = v.scale
+		if v.unscaled.Sign() < 0 {
+			sign = numericNeg
+		}
+		// pad the fractional and integer digits to whole base-10000 groups:
+		digits := new(big.Int).Abs(v.unscaled).String()
+		fracLen := v.scale
+		if pad := (numericDigitsPerGroup - fracLen%numericDigitsPerGroup) % numericDigitsPerGroup; pad != 0 {
+			digits += strings.Repeat("0", pad)
+			fracLen += pad
+		}
+		intLen := len(digits) - fracLen
+		if intLen < 0 {
+			digits = strings.Repeat("0", -intLen) + digits
+			intLen = 0
+		}
+		if pad := (numericDigitsPerGroup - intLen%numericDigitsPerGroup) % numericDigitsPerGroup; pad != 0 {
+			digits = strings.Repeat("0", pad) + digits
+			intLen += pad
+		}
+		weight = intLen/numericDigitsPerGroup - 1
+		for i := 0; i < len(digits); i += numericDigitsPerGroup {
+			n, _ := strconv.Atoi(digits[i : i+numericDigitsPerGroup])
+			groups = append(groups, uint16(n))
+		}
+		// leading and trailing zero groups are implied by the weight and scale:
+		for len(groups) != 0 && groups[0] == 0 {
+			groups = groups[1:]
+			weight--
+		}
+		for len(groups) != 0 && groups[len(groups)-1] == 0 {
+			groups = groups[:len(groups)-1]
+		}
+		if len(groups) == 0 {
+			weight, sign = 0, numericPos
+		}
+	}
+	if len(groups) > math.MaxInt16 || weight > math.MaxInt16 || weight < math.MinInt16 || dscale > numericMaxFracDigits {
+		return fmt.Errorf("Numeric value %s is out of range", v)
+	}
+
+	b := make([]byte, 12+2*len(groups))
+	binary.BigEndian.PutUint32(b, uint32(8+2*len(groups)))
+	binary.BigEndian.PutUint16(b[4:], uint16(len(groups)))
+	binary.BigEndian.PutUint16(b[6:], uint16(int16(weight)))
+	binary.BigEndian.PutUint16(b[8:], sign)
+	binary.BigEndian.PutUint16(b[10:], uint16(dscale))
+	for i, g := range groups {
+		binary.BigEndian.PutUint16(b[12+2*i:], g)
+	}
+	w.WriteBytes(b)
+	return nil
+}
+
+type numericScannerRat struct {
+	v *big.Rat
+}
+
+// NumericScannerRat returns a scanner which decodes numeric values into v.
+// NaN and infinite values cannot be decoded.
+func NumericScannerRat(v *big.Rat) pgx.Scanner {
+	return numericScannerRat{v}
+}
+
+func (s numericScannerRat) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericScannerRat) ScanValue(vr ValueReader) error {
+	d := decodeFiniteNumeric(vr, "*big.Rat")
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	s.v.SetFrac(d.unscaled, pow10(d.scale))
+	return nil
+}
+
+type numericScannerInt struct {
+	v *big.Int
+}
+
+// NumericScannerInt returns a scanner which decodes numeric values into v.
+// Values with a non-zero fractional part, NaN and infinite values cannot be
+// decoded.
+func NumericScannerInt(v *big.Int) pgx.Scanner {
+	return numericScannerInt{v}
+}
+
+func (s numericScannerInt) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericScannerInt) ScanValue(vr ValueReader) error {
+	n := decodeNumericInt(vr, "*big.Int")
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	s.v.Set(n)
+	return nil
+}
+
+type numericScannerString struct {
+	v *string
+}
+
+// NumericScannerString returns a scanner which decodes numeric values into v,
+// as decimal strings with the scale of each value (or NaN, Infinity or
+// -Infinity).
+func NumericScannerString(v *string) pgx.Scanner {
+	return numericScannerString{v}
+}
+
+func (s numericScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericScannerString) ScanValue(vr ValueReader) error {
+	d := decodeNumeric(vr)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	*s.v = d.String()
+	return nil
+}
+
+type numericScannerInt64 struct {
+	v *int64
+}
+
+// NumericScannerInt64 returns a scanner which decodes numeric values into v.
+// Values with a non-zero fractional part, values out of range for int64, NaN
+// and infinite values cannot be decoded.
+func NumericScannerInt64(v *int64) pgx.Scanner {
+	return numericScannerInt64{v}
+}
+
+func (s numericScannerInt64) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericScannerInt64) ScanValue(vr ValueReader) error {
+	n := decodeNumericInt(vr, "int64")
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	if !n.IsInt64() {
+		vr.Fatal(fmt.Errorf("Numeric value %s is out of range for int64", n))
+		return vr.Err()
+	}
+	*s.v = n.Int64()
+	return nil
+}
+
+type numericScannerFloat64 struct {
+	v *float64
+}
+
+// NumericScannerFloat64 returns a scanner which decodes numeric values into v.
+// Values which cannot be represented by a float64 without a loss of precision
+// (i.e. whose shortest float64 representation differs) cannot be decoded.
+func NumericScannerFloat64(v *float64) pgx.Scanner {
+	return numericScannerFloat64{v}
+}
+
+func (s numericScannerFloat64) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericScannerFloat64) ScanValue(vr ValueReader) error {
+	d := decodeNumeric(vr)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	switch d.special {
+	case numericNaN:
+		*s.v = math.NaN()
+		return nil
+	case numericPInf:
+		*s.v = math.Inf(1)
+		return nil
+	case numericNInf:
+		*s.v = math.Inf(-1)
+		return nil
+	}
+	r := new(big.Rat).SetFrac(d.unscaled, pow10(d.scale))
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		vr.Fatal(fmt.Errorf("Numeric value %s is out of range for float64", d))
+		return vr.Err()
+	}
+	if back, ok := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64)); !ok || back.Cmp(r) != 0 {
+		vr.Fatal(fmt.Errorf("Numeric value %s cannot be decoded into float64 without a loss of precision", d))
+		return vr.Err()
+	}
+	*s.v = f
+	return nil
+}
+
+// decode a numeric value, rejecting NaN and infinite values
+func decodeFiniteNumeric(vr ValueReader, into string) decimal {
+	d := decodeNumeric(vr)
+	if vr.Err() == nil && d.special != 0 {
+		vr.Fatal(fmt.Errorf("Cannot decode numeric %s into %s", d, into))
+	}
+	return d
+}
+
+// decode a numeric value with no fractional part as an integer
+func decodeNumericInt(vr ValueReader, into string) *big.Int {
+	d := decodeFiniteNumeric(vr, into)
+	if vr.Err() != nil {
+		return nil
+	}
+	n, r := new(big.Int).QuoRem(d.unscaled, pow10(d.scale), new(big.Int))
+	if r.Sign() != 0 {
+		vr.Fatal(fmt.Errorf("Cannot decode numeric %s with a fractional part into %s", d, into))
+		return nil
+	}
+	return n
+}
+
+func decodeNumeric(vr ValueReader) decimal {
+	size := vr.Len()
+	if size == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into numeric"))
+		return decimal{}
+	}
+
+	if vr.Type().DataType != NumericOid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into numeric", vr.Type().DataType)))
+		return decimal{}
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return decimal{}
+	}
+
+	return decodeNumericValue(vr, size)
+}
+
+// decode a numeric value of the given size, following its length prefix
+func decodeNumericValue(vr ValueReader, size int32) decimal {
+	if size < 8 {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a numeric: %d", size)))
+		return decimal{}
+	}
+
+	ndigits := int(vr.ReadInt16())
+	weight := int(vr.ReadInt16())
+	sign := uint16(vr.ReadInt16())
+	dscale := int(uint16(vr.ReadInt16()))
+	if ndigits < 0 || size != int32(8+2*ndigits) {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a numeric with %d digits: %d", ndigits, size)))
+		return decimal{}
+	}
+	switch sign {
+	case numericNaN, numericPInf, numericNInf:
+		return decimal{special: sign}
+	case numericPos, numericNeg:
+	default:
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid sign for a numeric: %#x", sign)))
+		return decimal{}
+	}
+
+	n, base := new(big.Int), big.NewInt(10000)
+	for i := 0; i < ndigits; i++ {
+		digit := vr.ReadInt16()
+		if digit < 0 || digit >= 10000 {
+			vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid digit for a numeric: %d", digit)))
+			return decimal{}
+		}
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+	if vr.Err() != nil {
+		return decimal{}
+	}
+	// the value is n * 10^exp, shown with dscale fractional digits (trailing
+	// digits beyond dscale are kept if non-zero):
+	d := decimal{unscaled: n, scale: dscale}
+	if exp := numericDigitsPerGroup * (weight - ndigits + 1); exp+dscale >= 0 {
+		n.Mul(n, pow10(exp+dscale))
+	} else if q, r := new(big.Int).QuoRem(n, pow10(-exp-dscale), new(big.Int)); r.Sign() == 0 {
+		d.unscaled = q
+	} else {
+		d.scale = -exp
+	}
+	if sign == numericNeg {
+		d.unscaled.Neg(d.unscaled)
+	}
+	return d
+}
+
+type numericArrayEncoder struct {
+	v   []decimal
+	err error
+}
+
+func newNumericArrayEncoder(n int, elem func(i int) (decimal, error), precision, scale int) pgx.Encoder {
+	vs := make([]decimal, n)
+	for i := range vs {
+		d, err := elem(i)
+		if err == nil {
+			d, err = d.fit(precision, scale)
+		}
+		if err != nil {
+			return &numericArrayEncoder{err: err}
+		}
+		vs[i] = d
+	}
+	return &numericArrayEncoder{v: vs}
+}
+
+// NumericArrayEncoderRat returns an encoder which writes v as a numeric[]
+// value. If precision is non-zero, each element must fit within
+// numeric(precision,scale) without rounding. Nil elements cannot be encoded.
+func NumericArrayEncoderRat(v []*big.Rat, precision, scale int) pgx.Encoder {
+	return newNumericArrayEncoder(len(v), func(i int) (decimal, error) {
+		if v[i] == nil {
+			return decimal{}, fmt.Errorf("NumericArrayEncoder cannot encode a nil element")
+		}
+		return ratDecimal(v[i])
+	}, precision, scale)
+}
+
+// NumericArrayEncoderString returns an encoder which writes the decimal strings
+// of v as a numeric[] value. If precision is non-zero, each element must fit
+// within numeric(precision,scale) without rounding.
+func NumericArrayEncoderString(v []string, precision, scale int) pgx.Encoder {
+	return newNumericArrayEncoder(len(v), func(i int) (decimal, error) {
+		return parseDecimal(v[i])
+	}, precision, scale)
+}
+
+func (e *numericArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *numericArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *numericArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != NumericArrayOid {
+		return fmt.Errorf("NumericArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	if e.err != nil {
+		return e.err
+	}
+
+	return encodeArray(w, NumericOid, len(e.v), func(w ValueWriter, i int) error {
+		return encodeNumeric(w, e.v[i])
+	})
+}
+
+type numericArrayScannerRat struct {
+	v *[]*big.Rat
+}
+
+// NumericArrayScannerRat returns a scanner which decodes numeric[] values into
+// v. NaN and infinite elements cannot be decoded.
+func NumericArrayScannerRat(v *[]*big.Rat) pgx.Scanner {
+	return numericArrayScannerRat{v}
+}
+
+func (s numericArrayScannerRat) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericArrayScannerRat) ScanValue(vr ValueReader) error {
+	ds := decodeNumericArray(vr)
+	if vr.Err() != nil || ds == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]*big.Rat, len(ds))
+	for i, d := range ds {
+		if d.special != 0 {
+			vr.Fatal(fmt.Errorf("Cannot decode numeric %s into *big.Rat", d))
+			return vr.Err()
+		}
+		a[i] = new(big.Rat).SetFrac(d.unscaled, pow10(d.scale))
+	}
+	*s.v = a
+	return nil
+}
+
+type numericArrayScannerString struct {
+	v *[]string
+}
+
+// NumericArrayScannerString returns a scanner which decodes numeric[] values
+// into v, as decimal strings with the scale of each element (or NaN, Infinity
+// or -Infinity).
+func NumericArrayScannerString(v *[]string) pgx.Scanner {
+	return numericArrayScannerString{v}
+}
+
+func (s numericArrayScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s numericArrayScannerString) ScanValue(vr ValueReader) error {
+	ds := decodeNumericArray(vr)
+	if vr.Err() != nil || ds == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]string, len(ds))
+	for i, d := range ds {
+		a[i] = d.String()
+	}
+	*s.v = a
+	return nil
+}
+
+func decodeNumericArray(vr ValueReader) []decimal {
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	numElems := decodeArrayHeader(vr, NumericArrayOid, "numeric[]")
+	if vr.Err() != nil {
+		return nil
+	}
+
+	a := make([]decimal, numElems)
+	for i := 0; i < len(a); i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil {
+			return nil
+		}
+		if a[i] = decodeNumericValue(vr, elSize); vr.Err() != nil {
+			return nil
+		}
+	}
+
+	return a
+}
@@ -0,0 +1,137 @@
+package pgtypes
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/wdamron/pgx"
+)
+
+// numericValue encodes a single numeric value, returning a ValueReader for
+// the encoded value or the error returned by the encoder
+func numericValue(t *testing.T, e pgx.Encoder) (*CopyValue, error) {
+	t.Helper()
+	var buf copyBuf
+	if err := e.(ValueEncoder).EncodeValue(&buf, NumericOid); err != nil {
+		return nil, err
+	}
+	return binaryValue(NumericOid, buf), nil
+}
+
+func TestNumericString(t *testing.T) {
+	tests := []struct {
+		in, want         string
+		precision, scale int
+	}{
+		{in: "0", want: "0"},
+		{in: "0.00", want: "0.00"},
+		{in: "123.45", want: "123.45"},
+		{in: "-0.005", want: "-0.005"},
+		{in: "1.50", want: "1.50"},
+		{in: "100000000", want: "100000000"},
+		{in: "12345678901234567890.0001", want: "12345678901234567890.0001"},
+		{in: "1e5", want: "100000"},
+		{in: "1.2e-3", want: "0.0012"},
+		{in: "NaN", want: "NaN"},
+		{in: "Infinity", want: "Infinity"},
+		{in: "-Infinity", want: "-Infinity"},
+		{in: "123.4", want: "123.40", precision: 5, scale: 2},
+	}
+	for _, tt := range tests {
+		v, err := numericValue(t, NumericEncoderString(tt.in, tt.precision, tt.scale))
+		if err != nil {
+			t.Errorf("%s: %v", tt.in, err)
+			continue
+		}
+		var got string
+		if err := NumericScannerString(&got).(ValueScanner).ScanValue(v); err != nil || got != tt.want {
+			t.Errorf("%s: got %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestNumericEncoding(t *testing.T) {
+	var buf copyBuf
+	if err := NumericEncoderString("123.45", 0, 0).(ValueEncoder).EncodeValue(&buf, NumericOid); err != nil {
+		t.Fatal(err)
+	}
+	// ndigits 2, weight 0, positive, dscale 2, base-10000 digits 123 and 4500
+	want := []byte{0, 0, 0, 12, 0, 2, 0, 0, 0, 0, 0, 2, 0, 123, 0x11, 0x94}
+	if string(buf) != string(want) {
+		t.Fatalf("123.45 encoded as %x; want %x", []byte(buf), want)
+	}
+}
+
+func TestNumericTypmodErrors(t *testing.T) {
+	for _, e := range []pgx.Encoder{
+		NumericEncoderString("1234.5", 5, 2),
+		NumericEncoderString("123.456", 5, 2),
+		NumericEncoderString("not a number", 0, 0),
+		NumericEncoderRat(big.NewRat(1, 3), 0, 0),
+	} {
+		if _, err := numericValue(t, e); err == nil {
+			t.Errorf("expected an error encoding %+v", e)
+		}
+	}
+}
+
+func TestNumericGoTypes(t *testing.T) {
+	r := big.NewRat(1, 8)
+	v, err := numericValue(t, NumericEncoderRat(r, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotRat big.Rat
+	if err := NumericScannerRat(&gotRat).(ValueScanner).ScanValue(v); err != nil || gotRat.Cmp(r) != 0 {
+		t.Fatal(err, gotRat.String())
+	}
+
+	var f float64
+	v, _ = numericValue(t, NumericEncoderFloat64(0.1, 0, 0))
+	if err := NumericScannerFloat64(&f).(ValueScanner).ScanValue(v); err != nil || f != 0.1 {
+		t.Fatal(err, f)
+	}
+	v, _ = numericValue(t, NumericEncoderFloat64(math.Inf(-1), 0, 0))
+	if err := NumericScannerFloat64(&f).(ValueScanner).ScanValue(v); err != nil || !math.IsInf(f, -1) {
+		t.Fatal(err, f)
+	}
+	v, _ = numericValue(t, NumericEncoderString("0.12345678901234567890123", 0, 0))
+	if err := NumericScannerFloat64(&f).(ValueScanner).ScanValue(v); err == nil {
+		t.Error("expected an error for a loss of precision")
+	}
+
+	var i int64
+	v, _ = numericValue(t, NumericEncoderInt64(-42000, 0, 0))
+	if err := NumericScannerInt64(&i).(ValueScanner).ScanValue(v); err != nil || i != -42000 {
+		t.Fatal(err, i)
+	}
+	v, _ = numericValue(t, NumericEncoderString("99999999999999999999", 0, 0))
+	if err := NumericScannerInt64(&i).(ValueScanner).ScanValue(v); err == nil {
+		t.Error("expected an error for an overflow")
+	}
+
+	var bi big.Int
+	v, _ = numericValue(t, NumericEncoderString("1.5", 0, 0))
+	if err := NumericScannerInt(&bi).(ValueScanner).ScanValue(v); err == nil {
+		t.Error("expected an error for a fractional value")
+	}
+}
+
+func TestNumericArray(t *testing.T) {
+	cr := copyRow(t, []pgx.Oid{NumericArrayOid, NumericArrayOid}, []pgx.Encoder{
+		NumericArrayEncoderString([]string{"1.5", "-20.125"}, 8, 3),
+		NumericArrayEncoderRat([]*big.Rat{big.NewRat(1, 4)}, 0, 0),
+	})
+	var ss []string
+	if err := scanField(t, cr, NumericArrayOid, NumericArrayScannerString(&ss)); err != nil || len(ss) != 2 || ss[0] != "1.500" || ss[1] != "-20.125" {
+		t.Fatal(err, ss)
+	}
+	var rs []*big.Rat
+	if err := scanField(t, cr, NumericArrayOid, NumericArrayScannerRat(&rs)); err != nil || len(rs) != 1 || rs[0].Cmp(big.NewRat(1, 4)) != 0 {
+		t.Fatal(err, rs)
+	}
+	if err := NumericArrayEncoderString([]string{"123456.1"}, 8, 3).(ValueEncoder).EncodeValue(&copyBuf{}, NumericArrayOid); err == nil {
+		t.Error("expected an error for an element which exceeds the precision")
+	}
+}
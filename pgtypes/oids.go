@@ -16,17 +16,24 @@ const (
 	Int8Oid, Int8ArrayOid                       = 20, 1016
 	Float4Oid, Float4ArrayOid                   = 700, 1021
 	Float8Oid, Float8ArrayOid                   = 701, 1022
-	ByteaOid                                    = 17
+	ByteaOid, ByteaArrayOid                     = 17, 1001
 	TextOid, TextArrayOid                       = 25, 1009
 	VarcharOid, VarcharArrayOid                 = 1043, 1015
 	OidOid                                      = 26
-	DateOid                                     = 1082
+	DateOid, DateArrayOid                       = 1082, 1182
 	TimestampOid, TimestampArrayOid             = 1114, 1115
 	TimestampTzOid, TimestampTzArrayOid         = 1184, 1185
-	JSONOid                                     = 114
-	UUIDOid                                     = 2950
-	HstoreOid                                   = 0    // hstore data types have a non-constant oid
-	JSONArrayOid                                = 199  // json[] data types are not currently supported
-	UUIDArrayOid                                = 2951 // uuid[] data types are not currently supported
-	XMLOid                                      = 142  // xml data types are not currently supported
+	JSONOid, JSONArrayOid                       = 114, 199
+	JSONBOid, JSONBArrayOid                     = 3802, 3807
+	UUIDOid, UUIDArrayOid                       = 2950, 2951
+	NumericOid, NumericArrayOid                 = 1700, 1231
+	IntervalOid, IntervalArrayOid               = 1186, 1187
+	TimeOid, TimeArrayOid                       = 1083, 1183
+	TimeTzOid, TimeTzArrayOid                   = 1266, 1270
+	InetOid, InetArrayOid                       = 869, 1041
+	CidrOid, CidrArrayOid                       = 650, 651
+	MacaddrOid, MacaddrArrayOid                 = 829, 1040
+	Macaddr8Oid, Macaddr8ArrayOid               = 774, 775
+	HstoreOid                                   = 0   // hstore data types have a non-constant oid
+	XMLOid                                      = 142 // xml data types are not currently supported
 )
@@ -0,0 +1,172 @@
+package pgtypes
+
+import (
+	"github.com/wdamron/pgx"
+)
+
+// The null wrapper types of pgx (pgx.NullInt32, etc.) only encode into a
+// *pgx.WriteBuf and only scan from a *pgx.ValueReader, so they are converted
+// into the equivalent encoders and scanners within this package when values
+// are written to or read from binary COPY streams.
+
+// nullValueEncoder returns a ValueEncoder which writes the value held by e
+// into the given oid, if e is a (pointer to a) pgx null wrapper type.
+func nullValueEncoder(e pgx.Encoder, oid pgx.Oid) (ValueEncoder, bool) {
+	switch v := e.(type) {
+	case *pgx.NullBool:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullInt16:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullInt32:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullInt64:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullFloat32:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullFloat64:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullString:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	case *pgx.NullTime:
+		if v == nil {
+			return &nullEncoder{oid, BinaryFormatCode}, true
+		}
+		e = *v
+	}
+
+	var enc pgx.Encoder
+	switch v := e.(type) {
+	case pgx.NullBool:
+		if v.Valid {
+			enc = BoolEncoder(v.Bool)
+		}
+	case pgx.NullInt16:
+		if v.Valid {
+			enc = Int2Encoder(v.Int16)
+		}
+	case pgx.NullInt32:
+		if v.Valid {
+			enc = Int4Encoder(v.Int32)
+		}
+	case pgx.NullInt64:
+		if v.Valid {
+			enc = Int8Encoder(v.Int64)
+		}
+	case pgx.NullFloat32:
+		if v.Valid {
+			enc = Float4Encoder(v.Float32)
+		}
+	case pgx.NullFloat64:
+		if v.Valid {
+			enc = Float8Encoder(v.Float64)
+		}
+	case pgx.NullString:
+		if v.Valid && oid == VarcharOid {
+			enc = VarcharEncoder(v.String)
+		} else if v.Valid {
+			enc = TextEncoder(v.String)
+		}
+	case pgx.NullTime:
+		if v.Valid {
+			switch oid {
+			case DateOid:
+				enc = DateEncoder(v.Time)
+			case TimestampOid:
+				enc = TimestampEncoder(v.Time)
+			default:
+				enc = TimestampTzEncoder(v.Time)
+			}
+		}
+	default:
+		return nil, false
+	}
+	if enc == nil {
+		return &nullEncoder{oid, BinaryFormatCode}, true
+	}
+	return enc.(ValueEncoder), true
+}
+
+type pgxNullScanner struct {
+	s pgx.Scanner
+}
+
+// ValueScannerOf returns s as a ValueScanner, if s is one of the scanners
+// within this package or a pointer to a pgx null wrapper type, so that s may
+// decode values read from binary COPY streams (see CopyReader).
+func ValueScannerOf(s pgx.Scanner) (ValueScanner, bool) {
+	switch vs := s.(type) {
+	case ValueScanner:
+		return vs, true
+	case *pgx.NullBool, *pgx.NullInt16, *pgx.NullInt32, *pgx.NullInt64,
+		*pgx.NullFloat32, *pgx.NullFloat64, *pgx.NullString, *pgx.NullTime:
+		return pgxNullScanner{s}, true
+	}
+	return nil, false
+}
+
+func (s pgxNullScanner) Scan(vr *pgx.ValueReader) error {
+	return s.s.Scan(vr)
+}
+
+func (s pgxNullScanner) ScanValue(vr ValueReader) error {
+	null := vr.Len() == -1
+	var scanner pgx.Scanner
+	switch v := s.s.(type) {
+	case *pgx.NullBool:
+		*v = pgx.NullBool{Valid: !null}
+		scanner = BoolScanner(&v.Bool)
+	case *pgx.NullInt16:
+		*v = pgx.NullInt16{Valid: !null}
+		scanner = Int2Scanner(&v.Int16)
+	case *pgx.NullInt32:
+		*v = pgx.NullInt32{Valid: !null}
+		scanner = Int4Scanner(&v.Int32)
+	case *pgx.NullInt64:
+		*v = pgx.NullInt64{Valid: !null}
+		scanner = Int8Scanner(&v.Int64)
+	case *pgx.NullFloat32:
+		*v = pgx.NullFloat32{Valid: !null}
+		scanner = Float4Scanner(&v.Float32)
+	case *pgx.NullFloat64:
+		*v = pgx.NullFloat64{Valid: !null}
+		scanner = Float8Scanner(&v.Float64)
+	case *pgx.NullString:
+		*v = pgx.NullString{Valid: !null}
+		scanner = TextScanner(&v.String)
+	case *pgx.NullTime:
+		*v = pgx.NullTime{Valid: !null}
+		switch vr.Type().DataType {
+		case DateOid:
+			scanner = DateScanner(&v.Time)
+		case TimestampOid:
+			scanner = TimestampScanner(&v.Time)
+		default:
+			scanner = TimestampTzScanner(&v.Time)
+		}
+	}
+	if null {
+		return nil
+	}
+	return scanner.(ValueScanner).ScanValue(vr)
+}
@@ -7,6 +7,63 @@ import (
 	"github.com/wdamron/pgx"
 )
 
+type g_byteScanner struct {
+	v *byte
+}
+
+func IntoByte(v *byte) pgx.Scanner {
+	return g_byteScanner{v}
+}
+
+func (s g_byteScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_byteScanner) ScanValue(vr ValueReader) error {
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into byte"))
+		return vr.Err()
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return vr.Err()
+	}
+
+	switch vr.Type().DataType {
+	case BoolOid:
+		*s.v = vr.ReadUint8()
+		return vr.Err()
+	case Int2Oid:
+		v := vr.ReadInt16()
+		if v < 0 || v > math.MaxUint8 {
+			vr.Fatal(fmt.Errorf("%T %d out of range for byte", v, v))
+			return vr.Err()
+		}
+		*s.v = byte(v)
+		return vr.Err()
+	case Int4Oid:
+		v := vr.ReadInt32()
+		if v < 0 || v > math.MaxUint8 {
+			vr.Fatal(fmt.Errorf("%T %d out of range for byte", v, v))
+			return vr.Err()
+		}
+		*s.v = byte(v)
+		return vr.Err()
+	case Int8Oid:
+		v := vr.ReadInt64()
+		if v < 0 || v > math.MaxUint8 {
+			vr.Fatal(fmt.Errorf("%T %d out of range for byte", v, v))
+			return vr.Err()
+		}
+		*s.v = byte(v)
+		return vr.Err()
+	default:
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into byte", vr.Type().DataType)))
+		return vr.Err()
+	}
+}
+
 type g_int16Scanner struct {
 	v *int16
 }
@@ -16,6 +73,10 @@ func IntoInt16(v *int16) pgx.Scanner {
 }
 
 func (s g_int16Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_int16Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int16"))
 		return vr.Err()
@@ -28,7 +89,7 @@ func (s g_int16Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		*s.v = int16(vr.ReadByte())
+		*s.v = int16(vr.ReadUint8())
 		return vr.Err()
 	case Int2Oid:
 		*s.v = vr.ReadInt16()
@@ -64,6 +125,10 @@ func IntoUint16(v *uint16) pgx.Scanner {
 }
 
 func (s g_uint16Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_uint16Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int16"))
 		return vr.Err()
@@ -76,7 +141,7 @@ func (s g_uint16Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		v := int8(vr.ReadByte())
+		v := int8(vr.ReadUint8())
 		if v < 0 {
 			vr.Fatal(fmt.Errorf("Cannot decode negative value into uint16", v, v))
 			return vr.Err()
@@ -130,6 +195,10 @@ func IntoInt32(v *int32) pgx.Scanner {
 }
 
 func (s g_int32Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_int32Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int32"))
 		return vr.Err()
@@ -142,7 +211,7 @@ func (s g_int32Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		*s.v = int32(vr.ReadByte())
+		*s.v = int32(vr.ReadUint8())
 		return vr.Err()
 	case Int2Oid:
 		*s.v = int32(vr.ReadInt16())
@@ -173,6 +242,10 @@ func IntoUint32(v *uint32) pgx.Scanner {
 }
 
 func (s g_uint32Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_uint32Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into uint32"))
 		return vr.Err()
@@ -185,7 +258,7 @@ func (s g_uint32Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		v := int8(vr.ReadByte())
+		v := int8(vr.ReadUint8())
 		if v < 0 {
 			vr.Fatal(fmt.Errorf("Cannot decode negative value into uint32", v, v))
 			return vr.Err()
@@ -235,6 +308,10 @@ func IntoInt64(v *int64) pgx.Scanner {
 }
 
 func (s g_int64Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_int64Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int64"))
 		return vr.Err()
@@ -247,7 +324,7 @@ func (s g_int64Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		*s.v = int64(vr.ReadByte())
+		*s.v = int64(vr.ReadUint8())
 		return vr.Err()
 	case Int2Oid:
 		*s.v = int64(vr.ReadInt16())
@@ -273,6 +350,10 @@ func IntoUint64(v *uint64) pgx.Scanner {
 }
 
 func (s g_uint64Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_uint64Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into uint64"))
 		return vr.Err()
@@ -285,7 +366,7 @@ func (s g_uint64Scanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		v := int8(vr.ReadByte())
+		v := int8(vr.ReadUint8())
 		if v < 0 {
 			vr.Fatal(fmt.Errorf("Cannot decode negative value into uint64", v, v))
 			return vr.Err()
@@ -331,6 +412,10 @@ func IntoFloat32(v *float32) pgx.Scanner {
 }
 
 func (s g_float32Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_float32Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into float32"))
 		return vr.Err()
@@ -368,6 +453,10 @@ func IntoFloat64(v *float64) pgx.Scanner {
 }
 
 func (s g_float64Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_float64Scanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into float64"))
 		return vr.Err()
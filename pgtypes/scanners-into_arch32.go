@@ -18,6 +18,10 @@ func IntoInt(v *int) pgx.Scanner {
 }
 
 func (s g_intScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_intScanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int"))
 		return vr.Err()
@@ -30,7 +34,7 @@ func (s g_intScanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		*s.v = int(vr.ReadByte())
+		*s.v = int(vr.ReadUint8())
 		return vr.Err()
 	case Int2Oid:
 		*s.v = int(vr.ReadInt16())
@@ -61,6 +65,10 @@ func IntoUint(v *uint) pgx.Scanner {
 }
 
 func (s g_uintScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_uintScanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int"))
 		return vr.Err()
@@ -73,7 +81,7 @@ func (s g_uintScanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		v := int8(vr.ReadByte())
+		v := int8(vr.ReadUint8())
 		if v < 0 {
 			vr.Fatal(fmt.Errorf("Cannot decode negative value into uint", v, v))
 			return vr.Err()
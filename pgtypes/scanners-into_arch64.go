@@ -17,6 +17,10 @@ func IntoInt(v *int) pgx.Scanner {
 }
 
 func (s g_intScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_intScanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int"))
 		return vr.Err()
@@ -29,7 +33,7 @@ func (s g_intScanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		*s.v = int(vr.ReadByte())
+		*s.v = int(vr.ReadUint8())
 		return vr.Err()
 	case Int2Oid:
 		*s.v = int(vr.ReadInt16())
@@ -55,6 +59,10 @@ func IntoUint(v *uint) pgx.Scanner {
 }
 
 func (s g_uintScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s g_uintScanner) ScanValue(vr ValueReader) error {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int"))
 		return vr.Err()
@@ -67,7 +75,7 @@ func (s g_uintScanner) Scan(vr *pgx.ValueReader) error {
 
 	switch vr.Type().DataType {
 	case BoolOid:
-		v := int8(vr.ReadByte())
+		v := int8(vr.ReadUint8())
 		if v < 0 {
 			vr.Fatal(fmt.Errorf("Cannot decode negative value into uint", v, v))
 			return vr.Err()
@@ -1,7 +1,6 @@
 package pgtypes
 
 import (
-	"encoding/json"
 	"fmt"
 	"math"
 	"strconv"
@@ -11,13 +10,77 @@ import (
 	"github.com/wdamron/pgx"
 )
 
+// ValueReader is implemented by any source which encoded values may be read
+// from, such as a *pgx.ValueReader or a CopyValue (see CopyReader).
+type ValueReader interface {
+	Len() int32
+	Type() *pgx.FieldDescription
+	Fatal(err error)
+	Err() error
+	ReadUint8() byte
+	ReadInt16() int16
+	ReadInt32() int32
+	ReadInt64() int64
+	ReadString(count int32) string
+	ReadBytes(count int32) []byte
+}
+
+// pgxValueReader adapts a *pgx.ValueReader to ValueReader
+type pgxValueReader struct {
+	*pgx.ValueReader
+}
+
+func (vr pgxValueReader) ReadUint8() byte {
+	return vr.ReadByte()
+}
+
+// ValueScanner is implemented by the scanners within this package, which may
+// read encoded values from any ValueReader.
+type ValueScanner interface {
+	pgx.Scanner
+	ScanValue(vr ValueReader) error
+}
+
 type ScannerFunc func(*pgx.ValueReader) error
 
 func (fn ScannerFunc) Scan(vr *pgx.ValueReader) error {
 	return fn(vr)
 }
 
-func decodeBytes(vr *pgx.ValueReader) []byte {
+type nullableScanner struct {
+	clear func()
+	alloc func() pgx.Scanner
+}
+
+// NullableScanner returns a scanner for a pointer field, which calls clear if
+// the value is null, or otherwise calls alloc to allocate a new value for the
+// field and scans into the scanner returned by alloc.
+func NullableScanner(clear func(), alloc func() pgx.Scanner) pgx.Scanner {
+	return nullableScanner{clear, alloc}
+}
+
+func (s nullableScanner) Scan(vr *pgx.ValueReader) error {
+	if vr.Len() == -1 {
+		s.clear()
+		return nil
+	}
+	return s.alloc().Scan(vr)
+}
+
+func (s nullableScanner) ScanValue(vr ValueReader) error {
+	if vr.Len() == -1 {
+		s.clear()
+		return nil
+	}
+	scanner := s.alloc()
+	vs, ok := ValueScannerOf(scanner)
+	if !ok {
+		return fmt.Errorf("NullableScanner cannot scan into %T from a ValueReader", scanner)
+	}
+	return vs.ScanValue(vr)
+}
+
+func decodeBytes(vr ValueReader) []byte {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into []byte"))
 		return nil
@@ -25,7 +88,7 @@ func decodeBytes(vr *pgx.ValueReader) []byte {
 	return vr.ReadBytes(vr.Len())
 }
 
-func decodeString(vr *pgx.ValueReader) string {
+func decodeString(vr ValueReader) string {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into string"))
 		return ""
@@ -33,7 +96,7 @@ func decodeString(vr *pgx.ValueReader) string {
 	return vr.ReadString(vr.Len())
 }
 
-func decode1dArrayHeader(vr *pgx.ValueReader) (length int32, err error) {
+func decode1dArrayHeader(vr ValueReader) (length int32, err error) {
 	numDims := vr.ReadInt32()
 	if numDims > 1 {
 		return 0, pgx.ProtocolError(fmt.Sprintf("Expected array to have 0 or 1 dimension, but it had %v", numDims))
@@ -47,11 +110,7 @@ func decode1dArrayHeader(vr *pgx.ValueReader) (length int32, err error) {
 	}
 
 	length = vr.ReadInt32()
-
-	idxFirstElem := vr.ReadInt32()
-	if idxFirstElem != 1 {
-		return 0, pgx.ProtocolError(fmt.Sprintf("Expected array's first element to start a index 1, but it is %d", idxFirstElem))
-	}
+	vr.ReadInt32() // index of the first element, which cannot be represented by a slice
 
 	return length, nil
 }
@@ -65,11 +124,15 @@ func BoolScanner(v *bool) pgx.Scanner {
 }
 
 func (s boolScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s boolScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeBool(vr)
 	return vr.Err()
 }
 
-func decodeBool(vr *pgx.ValueReader) bool {
+func decodeBool(vr ValueReader) bool {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into bool"))
 		return false
@@ -90,7 +153,7 @@ func decodeBool(vr *pgx.ValueReader) bool {
 		return false
 	}
 
-	b := vr.ReadByte()
+	b := vr.ReadUint8()
 	return b != 0
 }
 
@@ -103,11 +166,15 @@ func Int2Scanner(v *int16) pgx.Scanner {
 }
 
 func (s int2Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int2Scanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt2(vr)
 	return vr.Err()
 }
 
-func decodeInt2(vr *pgx.ValueReader) int16 {
+func decodeInt2(vr ValueReader) int16 {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int16"))
 		return 0
@@ -140,11 +207,15 @@ func Int4Scanner(v *int32) pgx.Scanner {
 }
 
 func (s int4Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int4Scanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt4(vr)
 	return vr.Err()
 }
 
-func decodeInt4(vr *pgx.ValueReader) int32 {
+func decodeInt4(vr ValueReader) int32 {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int32"))
 		return 0
@@ -177,11 +248,15 @@ func Int8Scanner(v *int64) pgx.Scanner {
 }
 
 func (s int8Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int8Scanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt8(vr)
 	return vr.Err()
 }
 
-func decodeInt8(vr *pgx.ValueReader) int64 {
+func decodeInt8(vr ValueReader) int64 {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into int64"))
 		return 0
@@ -214,11 +289,15 @@ func Float4Scanner(v *float32) pgx.Scanner {
 }
 
 func (s float4Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s float4Scanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeFloat4(vr)
 	return vr.Err()
 }
 
-func decodeFloat4(vr *pgx.ValueReader) float32 {
+func decodeFloat4(vr ValueReader) float32 {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into float32"))
 		return 0
@@ -252,11 +331,15 @@ func Float8Scanner(v *float64) pgx.Scanner {
 }
 
 func (s float8Scanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s float8Scanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeFloat8(vr)
 	return vr.Err()
 }
 
-func decodeFloat8(vr *pgx.ValueReader) float64 {
+func decodeFloat8(vr ValueReader) float64 {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into float64"))
 		return 0
@@ -290,11 +373,33 @@ func ByteaScanner(v *[]byte) pgx.Scanner {
 }
 
 func (s byteaScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s byteaScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeBytea(vr)
 	return vr.Err()
 }
 
-func decodeBytea(vr *pgx.ValueReader) []byte {
+type byteaScannerString struct {
+	v *string
+}
+
+// ByteaScannerString returns a scanner which decodes bytea values into v.
+func ByteaScannerString(v *string) pgx.Scanner {
+	return byteaScannerString{v}
+}
+
+func (s byteaScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s byteaScannerString) ScanValue(vr ValueReader) error {
+	*s.v = string(decodeBytea(vr))
+	return vr.Err()
+}
+
+func decodeBytea(vr ValueReader) []byte {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -321,11 +426,15 @@ func TextScanner(v *string) pgx.Scanner {
 }
 
 func (s textScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s textScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeText(vr)
 	return vr.Err()
 }
 
-func decodeText(vr *pgx.ValueReader) string {
+func decodeText(vr ValueReader) string {
 	return decodeString(vr)
 }
 
@@ -333,7 +442,7 @@ func VarcharScanner(v *string) pgx.Scanner {
 	return TextScanner(v)
 }
 
-func decodeVarchar(vr *pgx.ValueReader) string {
+func decodeVarchar(vr ValueReader) string {
 	return decodeString(vr)
 }
 
@@ -346,12 +455,21 @@ func DateScanner(v *time.Time) pgx.Scanner {
 }
 
 func (s dateScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s dateScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeDate(vr)
 	return vr.Err()
 }
 
-func decodeDate(vr *pgx.ValueReader) time.Time {
-	var zeroTime time.Time
+func decodeDate(vr ValueReader) time.Time {
+	return decodeDateIn(vr, defaultLocation()).SentinelTime()
+}
+
+// decode a date value as midnight in loc
+func decodeDateIn(vr ValueReader, loc *time.Location) InfinityTime {
+	var zeroTime InfinityTime
 
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into time.Time"))
@@ -370,9 +488,9 @@ func decodeDate(vr *pgx.ValueReader) time.Time {
 
 	if vr.Len() != 4 {
 		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for an date: %d", vr.Len())))
+		return zeroTime
 	}
-	dayOffset := vr.ReadInt32()
-	return time.Date(2000, 1, int(1+dayOffset), 0, 0, 0, 0, time.Local)
+	return dateOf(vr.ReadInt32(), loc)
 }
 
 type timestampScanner struct {
@@ -384,12 +502,21 @@ func TimestampScanner(v *time.Time) pgx.Scanner {
 }
 
 func (s timestampScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timestampScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeTimestamp(vr)
 	return vr.Err()
 }
 
-func decodeTimestamp(vr *pgx.ValueReader) time.Time {
-	var zeroTime time.Time
+func decodeTimestamp(vr ValueReader) time.Time {
+	return decodeTimestampIn(vr, defaultLocation()).SentinelTime()
+}
+
+// decode a timestamp value as a wall clock time in loc
+func decodeTimestampIn(vr ValueReader, loc *time.Location) InfinityTime {
+	var zeroTime InfinityTime
 
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into timestamp"))
@@ -408,11 +535,10 @@ func decodeTimestamp(vr *pgx.ValueReader) time.Time {
 
 	if vr.Len() != 8 {
 		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for an timestamp: %d", vr.Len())))
+		return zeroTime
 	}
 
-	microsecSinceY2K := vr.ReadInt64()
-	microsecSinceUnixEpoch := microsecFromUnixEpochToY2K + microsecSinceY2K
-	return time.Unix(microsecSinceUnixEpoch/1000000, (microsecSinceUnixEpoch%1000000)*1000)
+	return timestampOf(vr.ReadInt64(), loc)
 }
 
 type timestampTzScanner struct {
@@ -424,12 +550,20 @@ func TimestampTzScanner(v *time.Time) pgx.Scanner {
 }
 
 func (s timestampTzScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timestampTzScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeTimestampTz(vr)
 	return vr.Err()
 }
 
-func decodeTimestampTz(vr *pgx.ValueReader) time.Time {
-	var zeroTime time.Time
+func decodeTimestampTz(vr ValueReader) time.Time {
+	return decodeTimestampTzIn(vr).SentinelTime()
+}
+
+func decodeTimestampTzIn(vr ValueReader) InfinityTime {
+	var zeroTime InfinityTime
 
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into time.Time"))
@@ -451,9 +585,7 @@ func decodeTimestampTz(vr *pgx.ValueReader) time.Time {
 		return zeroTime
 	}
 
-	microsecSinceY2K := vr.ReadInt64()
-	microsecSinceUnixEpoch := microsecFromUnixEpochToY2K + microsecSinceY2K
-	return time.Unix(microsecSinceUnixEpoch/1000000, (microsecSinceUnixEpoch%1000000)*1000)
+	return timestampOf(vr.ReadInt64(), nil)
 }
 
 type oidScanner struct {
@@ -465,11 +597,15 @@ func OidScanner(v *pgx.Oid) pgx.Scanner {
 }
 
 func (s oidScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s oidScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeOid(vr)
 	return vr.Err()
 }
 
-func decodeOid(vr *pgx.ValueReader) pgx.Oid {
+func decodeOid(vr ValueReader) pgx.Oid {
 	if vr.Len() == -1 {
 		vr.Fatal(pgx.ProtocolError("Cannot decode null into Oid"))
 		return 0
@@ -502,19 +638,30 @@ func decodeOid(vr *pgx.ValueReader) pgx.Oid {
 }
 
 type jsonScanner struct {
-	v interface{}
+	v     interface{}
+	codec string
 }
 
 func (s jsonScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonScanner) ScanValue(vr ValueReader) error {
 	b := decodeBytes(vr)
 	if vr.Err() != nil {
 		return vr.Err()
 	}
-	return json.Unmarshal(b, s.v)
+	return unmarshalJSON(s.codec, b, s.v)
 }
 
 func JSONScanner(v interface{}) pgx.Scanner {
-	return jsonScanner{v}
+	return jsonScanner{v, ""}
+}
+
+// JSONScannerCodec returns a scanner which unmarshals into v with the JSON
+// codec registered under codec (see RegisterJSONCodec).
+func JSONScannerCodec(v interface{}, codec string) pgx.Scanner {
+	return jsonScanner{v, codec}
 }
 
 type jsonScannerString struct {
@@ -526,11 +673,15 @@ func JSONScannerString(v *string) pgx.Scanner {
 }
 
 func (s jsonScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonScannerString) ScanValue(vr ValueReader) error {
 	*s.v = decodeJSONString(vr)
 	return vr.Err()
 }
 
-func decodeJSONString(vr *pgx.ValueReader) string {
+func decodeJSONString(vr ValueReader) string {
 	return decodeString(vr)
 }
 
@@ -543,14 +694,112 @@ func JSONScannerBytes(v *[]byte) pgx.Scanner {
 }
 
 func (s jsonScannerBytes) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonScannerBytes) ScanValue(vr ValueReader) error {
 	*s.v = decodeJSONBytes(vr)
 	return vr.Err()
 }
 
-func decodeJSONBytes(vr *pgx.ValueReader) []byte {
+func decodeJSONBytes(vr ValueReader) []byte {
 	return decodeBytes(vr)
 }
 
+type jsonbScanner struct {
+	v     interface{}
+	codec string
+}
+
+func (s jsonbScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonbScanner) ScanValue(vr ValueReader) error {
+	b := decodeJSONBBytes(vr)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	return unmarshalJSON(s.codec, b, s.v)
+}
+
+func JSONBScanner(v interface{}) pgx.Scanner {
+	return jsonbScanner{v, ""}
+}
+
+// JSONBScannerCodec returns a scanner which unmarshals into v with the JSON
+// codec registered under codec (see RegisterJSONCodec).
+func JSONBScannerCodec(v interface{}, codec string) pgx.Scanner {
+	return jsonbScanner{v, codec}
+}
+
+type jsonbScannerString struct {
+	v *string
+}
+
+func JSONBScannerString(v *string) pgx.Scanner {
+	return jsonbScannerString{v}
+}
+
+func (s jsonbScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonbScannerString) ScanValue(vr ValueReader) error {
+	*s.v = string(decodeJSONBBytes(vr))
+	return vr.Err()
+}
+
+type jsonbScannerBytes struct {
+	v *[]byte
+}
+
+func JSONBScannerBytes(v *[]byte) pgx.Scanner {
+	return jsonbScannerBytes{v}
+}
+
+func (s jsonbScannerBytes) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s jsonbScannerBytes) ScanValue(vr ValueReader) error {
+	*s.v = decodeJSONBBytes(vr)
+	return vr.Err()
+}
+
+// decode the JSON text of a jsonb value, stripping the version prefix of the
+// binary format
+func decodeJSONBBytes(vr ValueReader) []byte {
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into []byte"))
+		return nil
+	}
+
+	if vr.Type().DataType != JSONBOid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into jsonb", vr.Type().DataType)))
+		return nil
+	}
+
+	switch vr.Type().FormatCode {
+	case TextFormatCode:
+		return vr.ReadBytes(vr.Len())
+	case BinaryFormatCode:
+		size := vr.Len()
+		if size < 1 {
+			vr.Fatal(pgx.ProtocolError("Received an invalid size for a jsonb: 0"))
+			return nil
+		}
+		if version := vr.ReadUint8(); version != jsonbVersion {
+			vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown jsonb format version: %d", version)))
+			return nil
+		}
+		return vr.ReadBytes(size - 1)
+	default:
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return nil
+	}
+}
+
 type uuidScanner struct {
 	v *uuid.UUID
 }
@@ -560,11 +809,15 @@ func UUIDScanner(v *uuid.UUID) pgx.Scanner {
 }
 
 func (s uuidScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s uuidScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeUUID(vr)
 	return vr.Err()
 }
 
-func decodeUUID(vr *pgx.ValueReader) uuid.UUID {
+func decodeUUID(vr ValueReader) uuid.UUID {
 	var u uuid.UUID
 	switch vr.Len() {
 	case -1:
@@ -591,11 +844,15 @@ func UUIDScannerString(v *string) pgx.Scanner {
 }
 
 func (s uuidScannerString) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s uuidScannerString) ScanValue(vr ValueReader) error {
 	*s.v = decodeUUIDString(vr)
 	return vr.Err()
 }
 
-func decodeUUIDString(vr *pgx.ValueReader) string {
+func decodeUUIDString(vr ValueReader) string {
 	u := decodeUUID(vr)
 	if vr.Err() != nil {
 		return ""
@@ -612,11 +869,15 @@ func HstoreScanner(v *pgx.Hstore) pgx.Scanner {
 }
 
 func (s hstoreScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s hstoreScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeHstore(vr)
 	return vr.Err()
 }
 
-func decodeHstore(vr *pgx.ValueReader) pgx.Hstore {
+func decodeHstore(vr ValueReader) pgx.Hstore {
 	size := int(vr.ReadInt32())
 	h := make(pgx.Hstore, size)
 	for i := 0; i < size; i++ {
@@ -639,11 +900,15 @@ func HstoreMapScanner(v *map[string]string) pgx.Scanner {
 }
 
 func (s hstoreMapScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s hstoreMapScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeHstoreMap(vr)
 	return vr.Err()
 }
 
-func decodeHstoreMap(vr *pgx.ValueReader) map[string]string {
+func decodeHstoreMap(vr ValueReader) map[string]string {
 	size := int(vr.ReadInt32())
 	h := make(map[string]string, size)
 	for i := 0; i < size; i++ {
@@ -666,11 +931,15 @@ func BoolArrayScanner(v *[]bool) pgx.Scanner {
 }
 
 func (s boolArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s boolArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeBoolArray(vr)
 	return vr.Err()
 }
 
-func decodeBoolArray(vr *pgx.ValueReader) []bool {
+func decodeBoolArray(vr ValueReader) []bool {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -696,7 +965,7 @@ func decodeBoolArray(vr *pgx.ValueReader) []bool {
 		elSize := vr.ReadInt32()
 		switch elSize {
 		case 1:
-			if vr.ReadByte() == 1 {
+			if vr.ReadUint8() == 1 {
 				a[i] = true
 			}
 		case -1:
@@ -720,11 +989,15 @@ func Int2ArrayScanner(v *[]int16) pgx.Scanner {
 }
 
 func (s int2ArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int2ArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt2Array(vr)
 	return vr.Err()
 }
 
-func decodeInt2Array(vr *pgx.ValueReader) []int16 {
+func decodeInt2Array(vr ValueReader) []int16 {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -772,11 +1045,15 @@ func Int4ArrayScanner(v *[]int32) pgx.Scanner {
 }
 
 func (s int4ArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int4ArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt4Array(vr)
 	return vr.Err()
 }
 
-func decodeInt4Array(vr *pgx.ValueReader) []int32 {
+func decodeInt4Array(vr ValueReader) []int32 {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -824,11 +1101,15 @@ func Int8ArrayScanner(v *[]int64) pgx.Scanner {
 }
 
 func (s int8ArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s int8ArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeInt8Array(vr)
 	return vr.Err()
 }
 
-func decodeInt8Array(vr *pgx.ValueReader) []int64 {
+func decodeInt8Array(vr ValueReader) []int64 {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -876,11 +1157,15 @@ func Float4ArrayScanner(v *[]float32) pgx.Scanner {
 }
 
 func (s float4ArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s float4ArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeFloat4Array(vr)
 	return vr.Err()
 }
 
-func decodeFloat4Array(vr *pgx.ValueReader) []float32 {
+func decodeFloat4Array(vr ValueReader) []float32 {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -929,11 +1214,15 @@ func Float8ArrayScanner(v *[]float64) pgx.Scanner {
 }
 
 func (s float8ArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s float8ArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeFloat8Array(vr)
 	return vr.Err()
 }
 
-func decodeFloat8Array(vr *pgx.ValueReader) []float64 {
+func decodeFloat8Array(vr ValueReader) []float64 {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -982,11 +1271,15 @@ func TextArrayScanner(v *[]string) pgx.Scanner {
 }
 
 func (s textArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s textArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeTextArray(vr)
 	return vr.Err()
 }
 
-func decodeTextArray(vr *pgx.ValueReader) []string {
+func decodeTextArray(vr ValueReader) []string {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -1025,7 +1318,7 @@ func VarcharArrayScanner(v *[]string) pgx.Scanner {
 	return TextArrayScanner(v)
 }
 
-func decodeVarcharArray(vr *pgx.ValueReader) []string {
+func decodeVarcharArray(vr ValueReader) []string {
 	return decodeTextArray(vr)
 }
 
@@ -1038,11 +1331,15 @@ func TimestampArrayScanner(v *[]time.Time) pgx.Scanner {
 }
 
 func (s timestampArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timestampArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeTimestampArray(vr)
 	return vr.Err()
 }
 
-func decodeTimestampArray(vr *pgx.ValueReader) []time.Time {
+func decodeTimestampArray(vr ValueReader) []time.Time {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -1063,14 +1360,18 @@ func decodeTimestampArray(vr *pgx.ValueReader) []time.Time {
 		return nil
 	}
 
+	// timestamptz values are instants, and timestamp values are wall clock times:
+	var loc *time.Location
+	if vr.Type().DataType == TimestampArrayOid {
+		loc = defaultLocation()
+	}
+
 	a := make([]time.Time, int(numElems))
 	for i := 0; i < len(a); i++ {
 		elSize := vr.ReadInt32()
 		switch elSize {
 		case 8:
-			microsecSinceY2K := vr.ReadInt64()
-			microsecSinceUnixEpoch := microsecFromUnixEpochToY2K + microsecSinceY2K
-			a[i] = time.Unix(microsecSinceUnixEpoch/1000000, (microsecSinceUnixEpoch%1000000)*1000)
+			a[i] = timestampOf(vr.ReadInt64(), loc).SentinelTime()
 		case -1:
 			vr.Fatal(pgx.ProtocolError("Cannot decode null element"))
 			return nil
@@ -1087,7 +1388,7 @@ func TimestampTzArrayScanner(v *[]time.Time) pgx.Scanner {
 	return TimestampArrayScanner(v)
 }
 
-func decodeTimestampTzArray(vr *pgx.ValueReader) []time.Time {
+func decodeTimestampTzArray(vr ValueReader) []time.Time {
 	return decodeTimestampArray(vr)
 }
 
@@ -1100,11 +1401,15 @@ func UUIDArrayScanner(v *[]uuid.UUID) pgx.Scanner {
 }
 
 func (s uuidArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s uuidArrayScanner) ScanValue(vr ValueReader) error {
 	*s.v = decodeUUIDArray(vr)
 	return vr.Err()
 }
 
-func decodeUUIDArray(vr *pgx.ValueReader) []uuid.UUID {
+func decodeUUIDArray(vr ValueReader) []uuid.UUID {
 	if vr.Len() == -1 {
 		return nil
 	}
@@ -0,0 +1,64 @@
+package pgtypes
+
+import (
+	"strings"
+
+	"github.com/wdamron/pgx"
+)
+
+// MaxParams is the maximum number of bind parameters PostgreSQL accepts for
+// a single query/statement.
+const MaxParams = 65535
+
+// QuoteIdentifier returns name as a quoted SQL identifier, so that names which
+// are reserved words (e.g. "as") or contain upper-case letters may be used as
+// column and table names.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// QuoteIdentifiers returns names as quoted SQL identifiers (see
+// QuoteIdentifier).
+func QuoteIdentifiers(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = QuoteIdentifier(name)
+	}
+	return quoted
+}
+
+// QuoteTableName quotes each part of a table name which may be qualified with
+// a schema name, e.g. public.points -> "public"."points".
+func QuoteTableName(name string) string {
+	return strings.Join(QuoteIdentifiers(strings.Split(name, ".")), ".")
+}
+
+// Statement holds the SQL text for a query/statement along with the oids,
+// format codes and bound encoders for each of its parameters, in positional
+// order.
+type Statement struct {
+	SQL     string
+	Oids    []pgx.Oid
+	Formats []int
+	Args    []pgx.Encoder
+}
+
+// Params returns the bound parameter encoders of s as a slice of
+// interface{} values, suitable for passing to Conn.Query or Conn.Exec.
+func (s *Statement) Params() []interface{} {
+	params := make([]interface{}, len(s.Args))
+	for i, arg := range s.Args {
+		params[i] = arg
+	}
+	return params
+}
+
+// Querier is implemented by *pgx.Conn, *pgx.ConnPool and *pgx.Tx.
+type Querier interface {
+	Query(sql string, args ...interface{}) (*pgx.Rows, error)
+}
+
+// Execer is implemented by *pgx.Conn, *pgx.ConnPool and *pgx.Tx.
+type Execer interface {
+	Exec(sql string, args ...interface{}) (pgx.CommandTag, error)
+}
@@ -0,0 +1,431 @@
+package pgtypes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+const microsecPerDay = 24 * 60 * 60 * 1000000
+
+// TimeOfDay holds a time or timetz value. Offset is only encoded for timetz
+// values, and is zero when decoding time values.
+type TimeOfDay struct {
+	// microseconds since midnight, from 0 up to and including 24:00:00
+	Microseconds int64
+	// offset of the time zone in seconds east of UTC
+	Offset int32
+}
+
+// TimeOfDayOf returns the time of day of t, in the location of t.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	_, offset := t.Zone()
+	us := int64(t.Hour())*3600000000 + int64(t.Minute())*60000000 + int64(t.Second())*1000000 + int64(t.Nanosecond())/1000
+	return TimeOfDay{Microseconds: us, Offset: int32(offset)}
+}
+
+// Time returns v as a time on January 1, year 1, in a fixed zone with the
+// offset of v.
+func (v TimeOfDay) Time() time.Time {
+	loc := time.UTC
+	if v.Offset != 0 {
+		loc = time.FixedZone("", int(v.Offset))
+	}
+	return time.Date(1, time.January, 1, 0, 0, 0, 0, loc).Add(time.Duration(v.Microseconds) * time.Microsecond)
+}
+
+// Duration returns the time elapsed since midnight for v.
+func (v TimeOfDay) Duration() time.Duration {
+	return time.Duration(v.Microseconds) * time.Microsecond
+}
+
+func (v TimeOfDay) check() error {
+	if v.Microseconds < 0 || v.Microseconds > microsecPerDay {
+		return fmt.Errorf("Time of day is out of range: %s", v.Duration())
+	}
+	return nil
+}
+
+type timeEncoder struct {
+	v  TimeOfDay
+	tz bool
+}
+
+// TimeEncoder returns an encoder which writes the time of day of v, in the
+// location of v, as a time value.
+func TimeEncoder(v time.Time) pgx.Encoder {
+	return &timeEncoder{v: TimeOfDayOf(v)}
+}
+
+// TimeEncoderDuration returns an encoder which writes v, the time elapsed since
+// midnight, as a time value.
+func TimeEncoderDuration(v time.Duration) pgx.Encoder {
+	return &timeEncoder{v: TimeOfDay{Microseconds: int64(v / time.Microsecond)}}
+}
+
+// TimeEncoderTimeOfDay returns an encoder which writes v as a time value,
+// ignoring the offset of v.
+func TimeEncoderTimeOfDay(v TimeOfDay) pgx.Encoder {
+	return &timeEncoder{v: v}
+}
+
+// TimeTzEncoder returns an encoder which writes the time of day of v, along
+// with the offset of its location, as a timetz value.
+func TimeTzEncoder(v time.Time) pgx.Encoder {
+	return &timeEncoder{v: TimeOfDayOf(v), tz: true}
+}
+
+// TimeTzEncoderTimeOfDay returns an encoder which writes v, along with its
+// offset, as a timetz value.
+func TimeTzEncoderTimeOfDay(v TimeOfDay) pgx.Encoder {
+	return &timeEncoder{v: v, tz: true}
+}
+
+func (e *timeEncoder) FormatCode() int16 { return 1 }
+
+func (e *timeEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timeEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if !e.tz && oid != TimeOid {
+		return fmt.Errorf("TimeEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	if e.tz && oid != TimeTzOid {
+		return fmt.Errorf("TimeTzEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	if err := e.v.check(); err != nil {
+		return err
+	}
+
+	return encodeTimeOfDay(w, e.v, e.tz)
+}
+
+// encode a time value, or a timetz value if tz is true
+func encodeTimeOfDay(w ValueWriter, v TimeOfDay, tz bool) error {
+	x := v.Microseconds
+	b := []byte(len8)
+	if tz {
+		b = []byte(len12)
+	}
+	b = append(b, byte(x>>56), byte(x>>48), byte(x>>40), byte(x>>32))
+	b = append(b, byte(x>>24), byte(x>>16), byte(x>>8), byte(x))
+	if tz {
+		// the zone is encoded in seconds west of UTC:
+		z := -v.Offset
+		b = append(b, byte(z>>24), byte(z>>16), byte(z>>8), byte(z))
+	}
+	w.WriteBytes(b)
+	return nil
+}
+
+type timeScanner struct {
+	v  *time.Time
+	tz bool
+}
+
+// TimeScanner returns a scanner which decodes time values into v, as a time on
+// January 1, year 1, UTC.
+func TimeScanner(v *time.Time) pgx.Scanner {
+	return timeScanner{v: v}
+}
+
+// TimeTzScanner returns a scanner which decodes timetz values into v, as a time
+// on January 1, year 1, in a fixed zone with the offset of the value.
+func TimeTzScanner(v *time.Time) pgx.Scanner {
+	return timeScanner{v: v, tz: true}
+}
+
+func (s timeScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeScanner) ScanValue(vr ValueReader) error {
+	v := decodeTimeOfDay(vr, s.tz)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	*s.v = v.Time()
+	return nil
+}
+
+type timeScannerDuration struct {
+	v *time.Duration
+}
+
+// TimeScannerDuration returns a scanner which decodes time values into v, as
+// the time elapsed since midnight.
+func TimeScannerDuration(v *time.Duration) pgx.Scanner {
+	return timeScannerDuration{v}
+}
+
+func (s timeScannerDuration) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeScannerDuration) ScanValue(vr ValueReader) error {
+	v := decodeTimeOfDay(vr, false)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	*s.v = v.Duration()
+	return nil
+}
+
+type timeScannerTimeOfDay struct {
+	v  *TimeOfDay
+	tz bool
+}
+
+func TimeScannerTimeOfDay(v *TimeOfDay) pgx.Scanner {
+	return timeScannerTimeOfDay{v: v}
+}
+
+func TimeTzScannerTimeOfDay(v *TimeOfDay) pgx.Scanner {
+	return timeScannerTimeOfDay{v: v, tz: true}
+}
+
+func (s timeScannerTimeOfDay) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeScannerTimeOfDay) ScanValue(vr ValueReader) error {
+	v := decodeTimeOfDay(vr, s.tz)
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	*s.v = v
+	return nil
+}
+
+// decode a time value, or a timetz value if tz is true
+func decodeTimeOfDay(vr ValueReader, tz bool) TimeOfDay {
+	var oid pgx.Oid = TimeOid
+	size, name := int32(8), "time"
+	if tz {
+		oid, size, name = TimeTzOid, 12, "timetz"
+	}
+
+	if vr.Len() == -1 {
+		vr.Fatal(pgx.ProtocolError("Cannot decode null into " + name))
+		return TimeOfDay{}
+	}
+
+	if vr.Type().DataType != oid {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Cannot decode oid %v into %s", vr.Type().DataType, name)))
+		return TimeOfDay{}
+	}
+
+	if vr.Type().FormatCode != BinaryFormatCode {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Unknown field description format code: %v", vr.Type().FormatCode)))
+		return TimeOfDay{}
+	}
+
+	if vr.Len() != size {
+		vr.Fatal(pgx.ProtocolError(fmt.Sprintf("Received an invalid size for a %s: %d", name, vr.Len())))
+		return TimeOfDay{}
+	}
+
+	return decodeTimeOfDayBinary(vr, tz)
+}
+
+func decodeTimeOfDayBinary(vr ValueReader, tz bool) TimeOfDay {
+	v := TimeOfDay{Microseconds: vr.ReadInt64()}
+	if tz {
+		v.Offset = -vr.ReadInt32()
+	}
+	return v
+}
+
+type timeArrayEncoder struct {
+	v  []TimeOfDay
+	tz bool
+}
+
+// TimeArrayEncoder returns an encoder which writes the time of day of each
+// element of v, in the location of the element, as a time[] value.
+func TimeArrayEncoder(v []time.Time) pgx.Encoder {
+	a := make([]TimeOfDay, len(v))
+	for i, t := range v {
+		a[i] = TimeOfDayOf(t)
+	}
+	return &timeArrayEncoder{v: a}
+}
+
+// TimeArrayEncoderDuration returns an encoder which writes v, with elements
+// holding the time elapsed since midnight, as a time[] value.
+func TimeArrayEncoderDuration(v []time.Duration) pgx.Encoder {
+	a := make([]TimeOfDay, len(v))
+	for i, d := range v {
+		a[i] = TimeOfDay{Microseconds: int64(d / time.Microsecond)}
+	}
+	return &timeArrayEncoder{v: a}
+}
+
+// TimeArrayEncoderTimeOfDay returns an encoder which writes v as a time[]
+// value, ignoring the offset of each element.
+func TimeArrayEncoderTimeOfDay(v []TimeOfDay) pgx.Encoder {
+	return &timeArrayEncoder{v: v}
+}
+
+// TimeTzArrayEncoder returns an encoder which writes the time of day of each
+// element of v, along with the offset of its location, as a timetz[] value.
+func TimeTzArrayEncoder(v []time.Time) pgx.Encoder {
+	a := make([]TimeOfDay, len(v))
+	for i, t := range v {
+		a[i] = TimeOfDayOf(t)
+	}
+	return &timeArrayEncoder{v: a, tz: true}
+}
+
+func TimeTzArrayEncoderTimeOfDay(v []TimeOfDay) pgx.Encoder {
+	return &timeArrayEncoder{v: v, tz: true}
+}
+
+func (e *timeArrayEncoder) FormatCode() int16 { return 1 }
+
+func (e *timeArrayEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *timeArrayEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if !e.tz && oid != TimeArrayOid {
+		return fmt.Errorf("TimeArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	if e.tz && oid != TimeTzArrayOid {
+		return fmt.Errorf("TimeTzArrayEncoder.Encode cannot encode into OID: %d", oid)
+	}
+	for _, v := range e.v {
+		if err := v.check(); err != nil {
+			return err
+		}
+	}
+
+	if e.tz {
+		w.WriteBytes(encodeArrayHeaderBytes(TimeTzOid, len(e.v), 16))
+	} else {
+		w.WriteBytes(encodeArrayHeaderBytes(TimeOid, len(e.v), 12))
+	}
+	for _, v := range e.v {
+		if err := encodeTimeOfDay(w, v, e.tz); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type timeArrayScanner struct {
+	v  *[]time.Time
+	tz bool
+}
+
+// TimeArrayScanner returns a scanner which decodes time[] values into v, with
+// each element as a time on January 1, year 1, UTC.
+func TimeArrayScanner(v *[]time.Time) pgx.Scanner {
+	return timeArrayScanner{v: v}
+}
+
+// TimeTzArrayScanner returns a scanner which decodes timetz[] values into v,
+// with each element as a time on January 1, year 1, in a fixed zone with the
+// offset of the element.
+func TimeTzArrayScanner(v *[]time.Time) pgx.Scanner {
+	return timeArrayScanner{v: v, tz: true}
+}
+
+func (s timeArrayScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeArrayScanner) ScanValue(vr ValueReader) error {
+	vs := decodeTimeOfDayArray(vr, s.tz)
+	if vr.Err() != nil || vs == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]time.Time, len(vs))
+	for i, v := range vs {
+		a[i] = v.Time()
+	}
+	*s.v = a
+	return nil
+}
+
+type timeArrayScannerDuration struct {
+	v *[]time.Duration
+}
+
+// TimeArrayScannerDuration returns a scanner which decodes time[] values into
+// v, with each element as the time elapsed since midnight.
+func TimeArrayScannerDuration(v *[]time.Duration) pgx.Scanner {
+	return timeArrayScannerDuration{v}
+}
+
+func (s timeArrayScannerDuration) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeArrayScannerDuration) ScanValue(vr ValueReader) error {
+	vs := decodeTimeOfDayArray(vr, false)
+	if vr.Err() != nil || vs == nil {
+		*s.v = nil
+		return vr.Err()
+	}
+	a := make([]time.Duration, len(vs))
+	for i, v := range vs {
+		a[i] = v.Duration()
+	}
+	*s.v = a
+	return nil
+}
+
+type timeArrayScannerTimeOfDay struct {
+	v  *[]TimeOfDay
+	tz bool
+}
+
+func TimeArrayScannerTimeOfDay(v *[]TimeOfDay) pgx.Scanner {
+	return timeArrayScannerTimeOfDay{v: v}
+}
+
+func TimeTzArrayScannerTimeOfDay(v *[]TimeOfDay) pgx.Scanner {
+	return timeArrayScannerTimeOfDay{v: v, tz: true}
+}
+
+func (s timeArrayScannerTimeOfDay) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s timeArrayScannerTimeOfDay) ScanValue(vr ValueReader) error {
+	*s.v = decodeTimeOfDayArray(vr, s.tz)
+	return vr.Err()
+}
+
+// decode a time[] value, or a timetz[] value if tz is true
+func decodeTimeOfDayArray(vr ValueReader, tz bool) []TimeOfDay {
+	if vr.Len() == -1 {
+		return nil
+	}
+
+	var oid pgx.Oid = TimeArrayOid
+	size, name := int32(8), "time"
+	if tz {
+		oid, size, name = TimeTzArrayOid, 12, "timetz"
+	}
+
+	numElems := decodeArrayHeader(vr, oid, "[]pgtypes.TimeOfDay")
+	if vr.Err() != nil {
+		return nil
+	}
+
+	a := make([]TimeOfDay, numElems)
+	for i := 0; i < len(a); i++ {
+		elSize := decodeArrayElementSize(vr)
+		if vr.Err() != nil || !checkArrayElementSize(vr, elSize, size, name) {
+			return nil
+		}
+		a[i] = decodeTimeOfDayBinary(vr, tz)
+	}
+
+	return a
+}
@@ -0,0 +1,102 @@
+package pgtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	at := time.Date(2021, 5, 6, 13, 14, 15, 16000, time.FixedZone("", 2*3600))
+	oids := []pgx.Oid{TimeOid, TimeTzOid, TimeOid, TimeTzOid}
+	cr := copyRow(t, oids, []pgx.Encoder{
+		TimeEncoder(at),
+		TimeTzEncoder(at),
+		TimeEncoderDuration(90 * time.Minute),
+		TimeTzEncoderTimeOfDay(TimeOfDay{Microseconds: 1, Offset: -3600}),
+	})
+	// time values are decoded on 0001-01-01 UTC
+	var t1 time.Time
+	if err := scanField(t, cr, TimeOid, TimeScanner(&t1)); err != nil || !t1.Equal(time.Date(1, 1, 1, 13, 14, 15, 16000, time.UTC)) {
+		t.Fatal(err, t1)
+	}
+	var t2 time.Time
+	if err := scanField(t, cr, TimeTzOid, TimeTzScanner(&t2)); err != nil || t2.Hour() != 13 || t2.Minute() != 14 {
+		t.Fatal(err, t2)
+	}
+	if _, offset := t2.Zone(); offset != 2*3600 {
+		t.Fatalf("offset = %d; want %d", offset, 2*3600)
+	}
+	var d time.Duration
+	if err := scanField(t, cr, TimeOid, TimeScannerDuration(&d)); err != nil || d != 90*time.Minute {
+		t.Fatal(err, d)
+	}
+	var tod TimeOfDay
+	if err := scanField(t, cr, TimeTzOid, TimeTzScannerTimeOfDay(&tod)); err != nil || tod != (TimeOfDay{Microseconds: 1, Offset: -3600}) {
+		t.Fatal(err, tod)
+	}
+}
+
+func TestTimeTzEncoding(t *testing.T) {
+	var buf copyBuf
+	if err := TimeTzEncoderTimeOfDay(TimeOfDay{Microseconds: 1, Offset: 3600}).(ValueEncoder).EncodeValue(&buf, TimeTzOid); err != nil {
+		t.Fatal(err)
+	}
+	// the zone of a timetz value is encoded in seconds west of UTC
+	var want copyBuf
+	want.WriteInt32(12)
+	want.WriteInt32(0)
+	want.WriteInt32(1)
+	want.WriteInt32(-3600)
+	if string(buf) != string(want) {
+		t.Fatalf("encoded as %x; want %x", []byte(buf), []byte(want))
+	}
+}
+
+func TestTimeOfDayRange(t *testing.T) {
+	// 24:00:00 is the greatest time of day
+	for _, d := range []time.Duration{0, 24 * time.Hour} {
+		if err := TimeEncoderDuration(d).(ValueEncoder).EncodeValue(&copyBuf{}, TimeOid); err != nil {
+			t.Errorf("%v: %v", d, err)
+		}
+	}
+	for _, d := range []time.Duration{-time.Microsecond, 24*time.Hour + time.Microsecond} {
+		if err := TimeEncoderDuration(d).(ValueEncoder).EncodeValue(&copyBuf{}, TimeOid); err == nil {
+			t.Errorf("%v: expected an out of range error", d)
+		}
+	}
+	if err := TimeArrayEncoderDuration([]time.Duration{25 * time.Hour}).(ValueEncoder).EncodeValue(&copyBuf{}, TimeArrayOid); err == nil {
+		t.Error("expected an out of range error for an array element")
+	}
+}
+
+func TestTimeArrays(t *testing.T) {
+	ds := []time.Duration{0, 90 * time.Minute, 24 * time.Hour}
+	tods := []TimeOfDay{{Microseconds: 3600000000, Offset: -18000}, {Microseconds: 1}}
+	cr := copyRow(t, []pgx.Oid{TimeArrayOid, TimeTzArrayOid, TimeTzArrayOid}, []pgx.Encoder{
+		TimeArrayEncoderDuration(ds),
+		TimeTzArrayEncoderTimeOfDay(tods),
+		TimeTzArrayEncoderTimeOfDay(tods),
+	})
+	var gotDs []time.Duration
+	if err := scanField(t, cr, TimeArrayOid, TimeArrayScannerDuration(&gotDs)); err != nil || len(gotDs) != len(ds) {
+		t.Fatal(err, gotDs)
+	}
+	for i := range ds {
+		if gotDs[i] != ds[i] {
+			t.Errorf("element %d = %v; want %v", i, gotDs[i], ds[i])
+		}
+	}
+	var gotTods []TimeOfDay
+	if err := scanField(t, cr, TimeTzArrayOid, TimeTzArrayScannerTimeOfDay(&gotTods)); err != nil || len(gotTods) != 2 || gotTods[0] != tods[0] || gotTods[1] != tods[1] {
+		t.Fatal(err, gotTods)
+	}
+	var ts []time.Time
+	if err := scanField(t, cr, TimeTzArrayOid, TimeTzArrayScanner(&ts)); err != nil || len(ts) != 2 || ts[0].Hour() != 1 {
+		t.Fatal(err, ts)
+	}
+	if _, offset := ts[0].Zone(); offset != -18000 {
+		t.Fatalf("offset = %d; want %d", offset, -18000)
+	}
+}
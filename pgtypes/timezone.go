@@ -0,0 +1,341 @@
+package pgtypes
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/wdamron/pgx"
+)
+
+// binary values of infinity and -infinity for date and timestamp[tz] values
+const (
+	dateInfinity         = math.MaxInt32
+	dateNegInfinity      = math.MinInt32
+	timestampInfinity    = math.MaxInt64
+	timestampNegInfinity = math.MinInt64
+)
+
+var timeSettings = struct {
+	sync.RWMutex
+	loc   *time.Location
+	named map[string]*time.Location
+	// sentinels for infinity and -infinity, which lie outside of the ranges of
+	// date and timestamp values by default
+	pos time.Time
+	neg time.Time
+}{
+	loc:   time.UTC,
+	named: map[string]*time.Location{},
+	pos:   time.Date(5874898, time.January, 1, 0, 0, 0, 0, time.UTC),
+	neg:   time.Date(-4713, time.January, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// SetLocation sets the location in which date and timestamp values are
+// interpreted, unless a location is given by name (see GetLocation). Scanned
+// dates are midnight in the location, and timestamps are encoded and scanned as
+// wall clock times in the location. The default location is UTC.
+func SetLocation(loc *time.Location) {
+	timeSettings.Lock()
+	timeSettings.loc = loc
+	timeSettings.Unlock()
+}
+
+// GetLocation returns the location loaded for name, for columns tagged with the
+// tz:{name} option (see TimestampEncoderLocation and DateScannerLocation), or
+// the location set by SetLocation if name is empty.
+func GetLocation(name string) (*time.Location, error) {
+	timeSettings.RLock()
+	loc := timeSettings.loc
+	if name != "" {
+		loc = timeSettings.named[name]
+	}
+	timeSettings.RUnlock()
+	if loc != nil {
+		return loc, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unknown time zone location: %s", name)
+	}
+	timeSettings.Lock()
+	timeSettings.named[name] = loc
+	timeSettings.Unlock()
+	return loc, nil
+}
+
+// SetInfinity sets the sentinel values which date, timestamp and timestamptz
+// values of -infinity and infinity are scanned into as time.Time, and which are
+// encoded as -infinity and infinity.
+func SetInfinity(negative, positive time.Time) {
+	timeSettings.Lock()
+	timeSettings.neg, timeSettings.pos = negative, positive
+	timeSettings.Unlock()
+}
+
+// GetInfinity returns the sentinel values for -infinity and infinity (see
+// SetInfinity).
+func GetInfinity() (negative, positive time.Time) {
+	timeSettings.RLock()
+	defer timeSettings.RUnlock()
+	return timeSettings.neg, timeSettings.pos
+}
+
+// InfinityTime holds a date, timestamp or timestamptz value which may be
+// infinity or -infinity.
+type InfinityTime struct {
+	Time time.Time
+	// 1 for infinity, -1 for -infinity, or 0 if Time holds the value
+	Infinity int8
+}
+
+// InfinityTimeOf returns v as an InfinityTime, which is infinite if v equals
+// one of the sentinel values (see SetInfinity).
+func InfinityTimeOf(v time.Time) InfinityTime {
+	neg, pos := GetInfinity()
+	switch {
+	case v.Equal(pos):
+		return InfinityTime{Infinity: 1}
+	case v.Equal(neg):
+		return InfinityTime{Infinity: -1}
+	}
+	return InfinityTime{Time: v}
+}
+
+// SentinelTime returns the time of v, or one of the sentinel values if v is
+// infinite (see SetInfinity).
+func (v InfinityTime) SentinelTime() time.Time {
+	if v.Infinity == 0 {
+		return v.Time
+	}
+	neg, pos := GetInfinity()
+	if v.Infinity > 0 {
+		return pos
+	}
+	return neg
+}
+
+func (v InfinityTime) String() string {
+	switch {
+	case v.Infinity > 0:
+		return "infinity"
+	case v.Infinity < 0:
+		return "-infinity"
+	}
+	return v.Time.String()
+}
+
+// days since 2000-01-01 for the calendar date of v, in the location of v
+func daysOf(v InfinityTime) int32 {
+	switch {
+	case v.Infinity > 0:
+		return dateInfinity
+	case v.Infinity < 0:
+		return dateNegInfinity
+	}
+	day := time.Date(v.Time.Year(), v.Time.Month(), v.Time.Day(), 0, 0, 0, 0, time.UTC)
+	return int32((day.Unix() - microsecFromUnixEpochToY2K/1000000) / 86400)
+}
+
+// midnight in loc for days since 2000-01-01
+func dateOf(days int32, loc *time.Location) InfinityTime {
+	switch days {
+	case dateInfinity:
+		return InfinityTime{Infinity: 1}
+	case dateNegInfinity:
+		return InfinityTime{Infinity: -1}
+	}
+	return InfinityTime{Time: time.Date(2000, 1, int(1+days), 0, 0, 0, 0, loc)}
+}
+
+// microseconds since 2000-01-01 UTC for v, or for the wall clock time of v in
+// loc if loc is non-nil (for timestamp values)
+func microsOf(v InfinityTime, loc *time.Location) int64 {
+	switch {
+	case v.Infinity > 0:
+		return timestampInfinity
+	case v.Infinity < 0:
+		return timestampNegInfinity
+	}
+	t := v.Time
+	if loc != nil {
+		t = t.In(loc)
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	}
+	return t.Unix()*1000000 + int64(t.Nanosecond())/1000 - microsecFromUnixEpochToY2K
+}
+
+// time for microseconds since 2000-01-01 UTC, or for the wall clock time in loc
+// if loc is non-nil (for timestamp values)
+func timestampOf(x int64, loc *time.Location) InfinityTime {
+	switch x {
+	case timestampInfinity:
+		return InfinityTime{Infinity: 1}
+	case timestampNegInfinity:
+		return InfinityTime{Infinity: -1}
+	}
+	microsecSinceUnixEpoch := microsecFromUnixEpochToY2K + x
+	t := time.Unix(microsecSinceUnixEpoch/1000000, (microsecSinceUnixEpoch%1000000)*1000)
+	if loc != nil {
+		t = t.UTC()
+		t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+	}
+	return InfinityTime{Time: t}
+}
+
+// default location for timestamp values, which is only unavailable if
+// SetLocation was given nil
+func defaultLocation() *time.Location {
+	loc, _ := GetLocation("")
+	if loc == nil {
+		return time.UTC
+	}
+	return loc
+}
+
+type infinityTimeEncoder struct {
+	name string
+	oid  pgx.Oid
+	v    InfinityTime
+	loc  string
+}
+
+// DateEncoderInfinity returns an encoder which writes v as a date value.
+func DateEncoderInfinity(v InfinityTime) pgx.Encoder {
+	return &infinityTimeEncoder{name: "DateEncoder", oid: DateOid, v: v}
+}
+
+// TimestampEncoderInfinity returns an encoder which writes v as a timestamp
+// value, in the location set by SetLocation.
+func TimestampEncoderInfinity(v InfinityTime) pgx.Encoder {
+	return &infinityTimeEncoder{name: "TimestampEncoder", oid: TimestampOid, v: v}
+}
+
+// TimestampEncoderLocation returns an encoder which writes the wall clock time
+// of v in the named location as a timestamp value (see GetLocation).
+func TimestampEncoderLocation(v time.Time, loc string) pgx.Encoder {
+	return &infinityTimeEncoder{name: "TimestampEncoder", oid: TimestampOid, v: InfinityTimeOf(v), loc: loc}
+}
+
+// TimestampEncoderInfinityLocation returns an encoder which writes the wall
+// clock time of v in the named location as a timestamp value (see
+// GetLocation).
+func TimestampEncoderInfinityLocation(v InfinityTime, loc string) pgx.Encoder {
+	return &infinityTimeEncoder{name: "TimestampEncoder", oid: TimestampOid, v: v, loc: loc}
+}
+
+// TimestampTzEncoderInfinity returns an encoder which writes v as a timestamptz
+// value.
+func TimestampTzEncoderInfinity(v InfinityTime) pgx.Encoder {
+	return &infinityTimeEncoder{name: "TimestampTzEncoder", oid: TimestampTzOid, v: v}
+}
+
+func (e *infinityTimeEncoder) FormatCode() int16 { return 1 }
+
+func (e *infinityTimeEncoder) Encode(wbuf *pgx.WriteBuf, oid pgx.Oid) error {
+	return e.EncodeValue(wbuf, oid)
+}
+
+func (e *infinityTimeEncoder) EncodeValue(w ValueWriter, oid pgx.Oid) error {
+	if oid != e.oid {
+		return fmt.Errorf("%s.Encode cannot encode into OID: %d", e.name, oid)
+	}
+
+	switch oid {
+	case DateOid:
+		return encodeInt4(w, daysOf(e.v))
+	case TimestampOid:
+		loc, err := GetLocation(e.loc)
+		if err != nil {
+			return err
+		}
+		return encodeInt8(w, microsOf(e.v, loc))
+	}
+	return encodeInt8(w, microsOf(e.v, nil))
+}
+
+type infinityTimeScanner struct {
+	oid pgx.Oid
+	// exactly one of v or t is non-nil
+	v   *InfinityTime
+	t   *time.Time
+	loc string
+}
+
+// DateScannerLocation returns a scanner which decodes date values into v, as
+// midnight in the named location (see GetLocation).
+func DateScannerLocation(v *time.Time, loc string) pgx.Scanner {
+	return &infinityTimeScanner{oid: DateOid, t: v, loc: loc}
+}
+
+// DateScannerInfinity returns a scanner which decodes date values into v, as
+// midnight in the location set by SetLocation.
+func DateScannerInfinity(v *InfinityTime) pgx.Scanner {
+	return &infinityTimeScanner{oid: DateOid, v: v}
+}
+
+// DateScannerInfinityLocation returns a scanner which decodes date values into
+// v, as midnight in the named location (see GetLocation).
+func DateScannerInfinityLocation(v *InfinityTime, loc string) pgx.Scanner {
+	return &infinityTimeScanner{oid: DateOid, v: v, loc: loc}
+}
+
+// TimestampScannerLocation returns a scanner which decodes timestamp values
+// into v, as wall clock times in the named location (see GetLocation).
+func TimestampScannerLocation(v *time.Time, loc string) pgx.Scanner {
+	return &infinityTimeScanner{oid: TimestampOid, t: v, loc: loc}
+}
+
+// TimestampScannerInfinity returns a scanner which decodes timestamp values
+// into v, as wall clock times in the location set by SetLocation.
+func TimestampScannerInfinity(v *InfinityTime) pgx.Scanner {
+	return &infinityTimeScanner{oid: TimestampOid, v: v}
+}
+
+// TimestampScannerInfinityLocation returns a scanner which decodes timestamp
+// values into v, as wall clock times in the named location (see GetLocation).
+func TimestampScannerInfinityLocation(v *InfinityTime, loc string) pgx.Scanner {
+	return &infinityTimeScanner{oid: TimestampOid, v: v, loc: loc}
+}
+
+// TimestampTzScannerInfinity returns a scanner which decodes timestamptz values
+// into v.
+func TimestampTzScannerInfinity(v *InfinityTime) pgx.Scanner {
+	return &infinityTimeScanner{oid: TimestampTzOid, v: v}
+}
+
+func (s *infinityTimeScanner) Scan(vr *pgx.ValueReader) error {
+	return s.ScanValue(pgxValueReader{vr})
+}
+
+func (s *infinityTimeScanner) ScanValue(vr ValueReader) error {
+	var x InfinityTime
+	switch s.oid {
+	case DateOid:
+		loc, err := GetLocation(s.loc)
+		if err != nil {
+			vr.Fatal(err)
+			return err
+		}
+		x = decodeDateIn(vr, loc)
+	case TimestampOid:
+		loc, err := GetLocation(s.loc)
+		if err != nil {
+			vr.Fatal(err)
+			return err
+		}
+		x = decodeTimestampIn(vr, loc)
+	default:
+		x = decodeTimestampTzIn(vr)
+	}
+	if vr.Err() != nil {
+		return vr.Err()
+	}
+	if s.v != nil {
+		*s.v = x
+	} else {
+		*s.t = x.SentinelTime()
+	}
+	return nil
+}
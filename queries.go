@@ -0,0 +1,205 @@
+package pgxgen
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// Query result kinds, given after the query name in a name annotation
+const (
+	// The query returns a single row
+	QueryOne = ":one"
+	// The query returns any number of rows
+	QueryMany = ":many"
+	// The query returns no rows
+	QueryExec = ":exec"
+)
+
+// Query annotation keys, given in -- {key}: {value} comments within a queries
+// file (see ParseQueries)
+const (
+	QueryNameKey   = "name"
+	QueryParamKey  = "param"
+	QueryResultKey = "result"
+	QueryColumnKey = "column"
+)
+
+// Query holds a named query parsed from a queries file
+type Query struct {
+	Name, Kind, SQL string
+	// Result names an existing tagged struct which results decode into, if any
+	Result string
+	// Params and Columns hold the declared parameters and result columns
+	Params  []QueryField
+	Columns []QueryField
+	Line    int
+}
+
+// QueryField holds a declared parameter or result column of a query. GoType
+// is empty unless declared explicitly.
+type QueryField struct {
+	Name, Type, GoType string
+	Line               int
+}
+
+// ParseQueries parses the annotated queries within src. Each query begins with
+// a name annotation, followed by annotations declaring its parameters and
+// results, and the query itself:
+//
+//	-- name: GetUserByEmail :one
+//	-- param: email varchar
+//	-- result: User
+//	SELECT * FROM users WHERE email = $1;
+//
+// Parameters and result columns are declared as {name} {coltype}, optionally
+// followed by a Go type. Results decode into the existing struct named by a
+// result annotation, or into a generated struct with the columns declared by
+// column annotations.
+func ParseQueries(src string) ([]Query, error) {
+	var queries []Query
+	var q *Query
+	var sql []string
+	finish := func() error {
+		if q == nil {
+			return nil
+		}
+		q.SQL = strings.TrimSuffix(strings.TrimSpace(strings.Join(sql, "\n")), ";")
+		if err := checkQuery(q); err != nil {
+			return err
+		}
+		queries = append(queries, *q)
+		return nil
+	}
+	for i, line := range strings.Split(src, "\n") {
+		lineno := i + 1
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "--") {
+			if q != nil {
+				sql = append(sql, line)
+			} else if trimmed != "" {
+				return nil, fmt.Errorf("line %d: query text before name annotation", lineno)
+			}
+			continue
+		}
+		kv := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(trimmed, "--")), ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, fields := strings.TrimSpace(kv[0]), strings.Fields(kv[1])
+		switch key {
+		case QueryNameKey:
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected -- name: {name} {:one|:many|:exec}", lineno)
+			}
+			q, sql = &Query{Name: fields[0], Kind: fields[1], Line: lineno}, nil
+		case QueryParamKey, QueryColumnKey:
+			if q == nil {
+				return nil, fmt.Errorf("line %d: %s annotation before name annotation", lineno, key)
+			}
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("line %d: expected -- %s: {name} {coltype} [gotype]", lineno, key)
+			}
+			// coltype may span several words (e.g. double precision):
+			field := QueryField{Name: fields[0], Type: strings.Join(fields[1:], " "), Line: lineno}
+			if len(fields) > 2 && NormalizeDataType(field.Type) == "" {
+				field.Type = strings.Join(fields[1:len(fields)-1], " ")
+				field.GoType = fields[len(fields)-1]
+			}
+			if key == QueryParamKey {
+				q.Params = append(q.Params, field)
+			} else {
+				q.Columns = append(q.Columns, field)
+			}
+		case QueryResultKey:
+			if q == nil {
+				return nil, fmt.Errorf("line %d: %s annotation before name annotation", lineno, key)
+			}
+			if len(fields) != 1 {
+				return nil, fmt.Errorf("line %d: expected -- result: {struct-name}", lineno)
+			}
+			q.Result = fields[0]
+		default:
+			if q != nil {
+				sql = append(sql, line)
+			}
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+// checkQuery returns an error if the annotations of q are inconsistent with
+// each other or with the parameter placeholders of the query
+func checkQuery(q *Query) error {
+	if !token.IsIdentifier(q.Name) || !token.IsExported(q.Name) {
+		return fmt.Errorf("line %d: query name must be an exported Go identifier: %s", q.Line, q.Name)
+	}
+	if q.SQL == "" {
+		return fmt.Errorf("line %d: query %s is empty", q.Line, q.Name)
+	}
+	hasResult := q.Result != "" || len(q.Columns) != 0
+	switch q.Kind {
+	case QueryOne, QueryMany:
+		if !hasResult {
+			return fmt.Errorf("line %d: query %s must declare a result or result columns", q.Line, q.Name)
+		}
+		if q.Result != "" && len(q.Columns) != 0 {
+			return fmt.Errorf("line %d: query %s cannot declare both a result and result columns", q.Line, q.Name)
+		}
+	case QueryExec:
+		if hasResult {
+			return fmt.Errorf("line %d: query %s returns no rows, but declares results", q.Line, q.Name)
+		}
+	default:
+		return fmt.Errorf("line %d: unknown result kind for query %s: %s", q.Line, q.Name, q.Kind)
+	}
+	names := map[string]bool{}
+	for _, p := range q.Params {
+		if !token.IsIdentifier(p.Name) || queryReservedNames[p.Name] || names[p.Name] {
+			return fmt.Errorf("line %d: invalid or duplicate parameter name for query %s: %s", p.Line, q.Name, p.Name)
+		}
+		// {name}Enc holds the encoder for a pointer parameter:
+		names[p.Name], names[p.Name+"Enc"] = true, true
+	}
+	if count := maxPlaceholder(q.SQL); count != len(q.Params) {
+		return fmt.Errorf("line %d: query %s has %d parameter placeholders, but declares %d parameters", q.Line, q.Name, count, len(q.Params))
+	}
+	return nil
+}
+
+// names used within generated query functions, which may not be used as
+// parameter names
+var queryReservedNames = map[string]bool{
+	"q": true, "e": true, "rows": true, "err": true, "v": true, "out": true,
+	"pgx": true, "pgtypes": true, "errors": true,
+}
+
+// maxPlaceholder returns the highest numbered $n placeholder within sql,
+// ignoring placeholders within quotes
+func maxPlaceholder(sql string) int {
+	max := 0
+	for i := 0; i < len(sql); i++ {
+		switch sql[i] {
+		case '\'', '"':
+			q := sql[i]
+			for i++; i < len(sql) && sql[i] != q; i++ {
+			}
+		case '$':
+			j := i + 1
+			for ; j < len(sql) && sql[j] >= '0' && sql[j] <= '9'; j++ {
+			}
+			if n, err := strconv.Atoi(sql[i+1 : j]); err == nil && n > max {
+				max = n
+			}
+			i = j - 1
+		}
+	}
+	return max
+}
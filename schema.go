@@ -0,0 +1,583 @@
+package pgxgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// Schema holds the tables and types parsed from a SQL schema (see ParseSchema)
+type Schema struct {
+	Tables []*SchemaTable
+	Types  []*SchemaType
+}
+
+// SchemaTable holds the columns and constraints of a table parsed from a
+// CREATE TABLE statement, along with any constraints added by ALTER TABLE
+type SchemaTable struct {
+	Name       string
+	Line       int
+	Columns    []SchemaColumn
+	PrimaryKey []string
+	Unique     []SchemaUnique
+}
+
+// SchemaColumn holds a column parsed from a CREATE TABLE statement
+type SchemaColumn struct {
+	Name, Type string
+	NotNull    bool
+	Default    string
+	Line       int
+}
+
+// SchemaUnique holds the columns of a unique constraint. The constraint name
+// is empty if the constraint was not named.
+type SchemaUnique struct {
+	Name    string
+	Columns []string
+}
+
+// SchemaType holds a user-defined type parsed from a CREATE TYPE statement.
+// Kind is "enum", "composite", "range" or "base".
+type SchemaType struct {
+	Name, Kind string
+	Line       int
+}
+
+// Table returns the table named name, or nil if the schema has no such table.
+// Unqualified names match tables within the public schema.
+func (sc *Schema) Table(name string) *SchemaTable {
+	for _, t := range sc.Tables {
+		if t.Name == name || t.Name == "public."+name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Type returns the user-defined type named name, or nil if the schema has no
+// such type. Unqualified names match types within the public schema.
+func (sc *Schema) Type(name string) *SchemaType {
+	for _, t := range sc.Types {
+		if t.Name == name || t.Name == "public."+name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Column returns the column of t named colname, or nil if t has no such column.
+func (t *SchemaTable) Column(colname string) *SchemaColumn {
+	for i := range t.Columns {
+		if t.Columns[i].Name == colname {
+			return &t.Columns[i]
+		}
+	}
+	return nil
+}
+
+// ParseSchema parses the CREATE TABLE and CREATE TYPE statements within src,
+// such as a schema-only dump from pg_dump. Primary key and unique constraints
+// added by ALTER TABLE statements are applied to their tables.
+//
+// Other statements are ignored.
+func ParseSchema(src string) (*Schema, error) {
+	src = stripSQLComments(src)
+	sc := &Schema{}
+	for _, stmt := range splitSQLStatements(src) {
+		text := src[stmt[0]:stmt[1]]
+		words := strings.Fields(text)
+		if len(words) < 3 {
+			continue
+		}
+		line := lineAt(src, stmt[0]+len(text)-len(strings.TrimLeftFunc(text, unicode.IsSpace)))
+		switch strings.ToUpper(words[0]) {
+		case "CREATE":
+			switch strings.ToUpper(words[1]) {
+			case "TYPE":
+				sc.Types = append(sc.Types, parseSchemaType(words, line))
+			case "TABLE", "GLOBAL", "LOCAL", "TEMP", "TEMPORARY", "UNLOGGED":
+				t, err := parseSchemaTable(src, stmt[0], text, line)
+				if err != nil {
+					return nil, err
+				}
+				if t != nil {
+					sc.Tables = append(sc.Tables, t)
+				}
+			}
+		case "ALTER":
+			if strings.ToUpper(words[1]) == "TABLE" {
+				alterSchemaTable(sc, text)
+			}
+		}
+	}
+	for _, t := range sc.Tables {
+		for _, colname := range t.PrimaryKey {
+			if c := t.Column(colname); c != nil {
+				c.NotNull = true
+			}
+		}
+	}
+	return sc, nil
+}
+
+// parse CREATE [GLOBAL|LOCAL] [TEMP|TEMPORARY|UNLOGGED] TABLE [IF NOT EXISTS]
+// name (...), returning nil if the statement does not define columns
+func parseSchemaTable(src string, offset int, stmt string, line int) (*SchemaTable, error) {
+	open := strings.IndexByte(stmt, '(')
+	if open < 0 {
+		return nil, nil
+	}
+	words := strings.Fields(stmt[:open])[1:]
+	for len(words) != 0 && strings.ToUpper(words[0]) != "TABLE" {
+		words = words[1:]
+	}
+	if len(words) < 2 {
+		return nil, nil
+	}
+	words = words[1:]
+	if len(words) == 4 && strings.ToUpper(strings.Join(words[:3], " ")) == "IF NOT EXISTS" {
+		words = words[3:]
+	}
+	if len(words) != 1 {
+		return nil, nil
+	}
+	body, close := sqlParenBody(stmt, open)
+	if close < 0 {
+		return nil, fmt.Errorf("unterminated CREATE TABLE statement at line %d", line)
+	}
+	t := &SchemaTable{Name: unquoteSQLName(words[0]), Line: line}
+	for _, def := range splitSQLList(body) {
+		raw := body[def[0]:def[1]]
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+		if !addSchemaConstraint(t, text) {
+			pos := offset + open + 1 + def[0] + len(raw) - len(strings.TrimLeftFunc(raw, unicode.IsSpace))
+			parseSchemaColumn(t, text, lineAt(src, pos))
+		}
+	}
+	return t, nil
+}
+
+// add a table constraint defined by def to t, returning false if def does not
+// define a table constraint
+func addSchemaConstraint(t *SchemaTable, def string) bool {
+	fields := strings.Fields(def)
+	name := ""
+	if strings.ToUpper(fields[0]) == "CONSTRAINT" {
+		if len(fields) < 3 {
+			return true
+		}
+		name = unquoteSQLName(fields[1])
+		fields = fields[2:]
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "PRIMARY":
+		t.PrimaryKey = append(t.PrimaryKey, sqlParenNames(def)...)
+	case "UNIQUE":
+		t.Unique = append(t.Unique, SchemaUnique{Name: name, Columns: sqlParenNames(def)})
+	case "CHECK", "FOREIGN", "EXCLUDE", "LIKE":
+	default:
+		return name != ""
+	}
+	return true
+}
+
+// apply ALTER TABLE [ONLY] [IF EXISTS] name ADD [CONSTRAINT name] PRIMARY KEY
+// (...) or UNIQUE (...), or ALTER TABLE [ONLY] [IF EXISTS] name ALTER [COLUMN]
+// name SET DEFAULT expr, to its table within sc
+func alterSchemaTable(sc *Schema, stmt string) {
+	words := strings.Fields(stmt)[2:]
+	for len(words) != 0 {
+		switch strings.ToUpper(words[0]) {
+		case "ONLY", "IF", "EXISTS":
+			words = words[1:]
+			continue
+		}
+		break
+	}
+	if len(words) < 3 {
+		return
+	}
+	t := sc.Table(unquoteSQLName(words[0]))
+	if t == nil {
+		return
+	}
+	switch strings.ToUpper(words[1]) {
+	case "ADD":
+		addSchemaConstraint(t, strings.Join(words[2:], " "))
+	case "ALTER":
+		words = words[2:]
+		if strings.ToUpper(words[0]) == "COLUMN" {
+			words = words[1:]
+		}
+		if len(words) < 4 || strings.ToUpper(words[1]) != "SET" || strings.ToUpper(words[2]) != "DEFAULT" {
+			return
+		}
+		if c := t.Column(unquoteSQLName(words[0])); c != nil {
+			c.Default = strings.Join(words[3:], " ")
+		}
+	}
+}
+
+// parse CREATE TYPE name [AS {ENUM|RANGE|(...)}]
+func parseSchemaType(words []string, line int) *SchemaType {
+	t := &SchemaType{Name: unquoteSQLName(strings.SplitN(words[2], "(", 2)[0]), Kind: "base", Line: line}
+	if len(words) > 3 && strings.ToUpper(words[3]) == "AS" {
+		t.Kind = "composite"
+		if len(words) > 4 {
+			switch kind := strings.ToUpper(strings.SplitN(words[4], "(", 2)[0]); kind {
+			case "ENUM", "RANGE":
+				t.Kind = strings.ToLower(kind)
+			}
+		}
+	}
+	return t
+}
+
+// column constraint keywords which end the data type of a column definition
+var schemaColumnKeywords = map[string]bool{
+	"NOT": true, "NULL": true, "DEFAULT": true, "CONSTRAINT": true, "PRIMARY": true,
+	"UNIQUE": true, "CHECK": true, "REFERENCES": true, "COLLATE": true, "GENERATED": true,
+}
+
+// integer types of the serial pseudo-types
+var schemaSerialTypes = map[string]string{
+	"smallserial": "smallint", "serial2": "smallint",
+	"serial": "integer", "serial4": "integer",
+	"bigserial": "bigint", "serial8": "bigint",
+}
+
+// parse a column definition, adding the column to t along with any inline
+// primary key or unique constraint
+func parseSchemaColumn(t *SchemaTable, def string, line int) {
+	fields := strings.Fields(def)
+	c := SchemaColumn{Name: unquoteSQLName(fields[0]), Line: line}
+	typeWords := []string{}
+	i := 1
+	for ; i < len(fields) && !schemaColumnKeywords[strings.ToUpper(fields[i])]; i++ {
+		typeWords = append(typeWords, fields[i])
+	}
+	c.Type = strings.ToLower(strings.Join(typeWords, " "))
+	// serial types are integer types with a default taken from a sequence
+	// named by PostgreSQL after the table and column:
+	if coltype, ok := schemaSerialTypes[c.Type]; ok {
+		c.Type, c.NotNull = coltype, true
+		c.Default = "nextval('" + t.Name + "_" + c.Name + "_seq'::regclass)"
+	}
+	for ; i < len(fields); i++ {
+		switch strings.ToUpper(fields[i]) {
+		case "NOT":
+			if i+1 < len(fields) && strings.ToUpper(fields[i+1]) == "NULL" {
+				c.NotNull = true
+			}
+		case "PRIMARY":
+			c.NotNull = true
+			t.PrimaryKey = append(t.PrimaryKey, c.Name)
+		case "UNIQUE":
+			t.Unique = append(t.Unique, SchemaUnique{Columns: []string{c.Name}})
+		case "DEFAULT":
+			j := i + 2
+			for ; j < len(fields) && !schemaColumnKeywords[strings.ToUpper(fields[j])]; j++ {
+			}
+			if i+1 < len(fields) {
+				c.Default = strings.Join(fields[i+1:j], " ")
+			}
+			i = j - 1
+		}
+	}
+	t.Columns = append(t.Columns, c)
+}
+
+// NormalizeSchemaType normalizes a data type as written in a schema, with any
+// type modifiers and public schema qualifiers removed (e.g. character
+// varying(255) -> varchar). Data types unknown to NormalizeDataType are
+// returned in lower case.
+func NormalizeSchemaType(dataType string) string {
+	dataType = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(dataType)), "public.")
+	for {
+		open := strings.IndexByte(dataType, '(')
+		if open < 0 {
+			break
+		}
+		close := strings.IndexByte(dataType[open:], ')')
+		if close < 0 {
+			break
+		}
+		dataType = strings.TrimSpace(dataType[:open]) + dataType[open+close+1:]
+	}
+	if strings.HasSuffix(dataType, "[]") {
+		dataType = NormalizeSchemaType(strings.TrimSuffix(dataType, "[]")) + "[]"
+	}
+	// some data type names are spelled in mixed case (e.g. timestampTz):
+	for name := range DataTypeNames {
+		if name != dataType && strings.EqualFold(name, dataType) {
+			dataType = name
+			break
+		}
+	}
+	if normalized := NormalizeDataType(dataType); normalized != "" {
+		return normalized
+	}
+	return dataType
+}
+
+// CheckSchema compares the columns of each struct of f against the tables
+// within schema, returning a description of each
+// mismatch, prefixed with the position of the struct or field within f.
+func (f *File) CheckSchema(schema *Schema) ([]string, error) {
+	positions, err := fieldPositions(f.File.Path)
+	if err != nil {
+		return nil, err
+	}
+	var mismatches []string
+	for _, s := range f.Structs {
+		if len(s.Columns) == 0 {
+			continue
+		}
+		report := func(field, format string, args ...interface{}) {
+			key, name := s.Name, s.Name
+			if field != "" {
+				key, name = s.Name+"."+field, s.Name+"."+field
+			}
+			mismatches = append(mismatches, fmt.Sprintf("%s: %s: %s", positions[key], name, fmt.Sprintf(format, args...)))
+		}
+		t := schema.Table(s.Table)
+		if t == nil {
+			report("", "table %s not found in schema", s.Table)
+			continue
+		}
+		for _, c := range s.Columns {
+			sc := t.Column(c.Name)
+			if sc == nil {
+				report(c.StructField.Name, "column %s not found in table %s", c.Name, t.Name)
+				continue
+			}
+			if schemaType := NormalizeSchemaType(sc.Type); collapseArrayDims(schemaType) != collapseArrayDims(c.Type) {
+				report(c.StructField.Name, "column %s has type %s, but the schema has type %s (line %d)", c.Name, c.Type, sc.Type, sc.Line)
+			} else if precision, scale := c.Numeric(); precision != 0 {
+				if sp, ss, _ := NumericTypmod(strings.TrimSuffix(sc.Type, "[]")); sp != precision || ss != scale {
+					report(c.StructField.Name, "column %s has type %s, but the schema has type %s (line %d)", c.Name, c.SQLType(), sc.Type, sc.Line)
+				}
+			}
+			if notNull := c.NotNull(); notNull != sc.NotNull {
+				report(c.StructField.Name, "column %s is %s, but the schema declares it %s (line %d)", c.Name, nullability(notNull), nullability(sc.NotNull), sc.Line)
+			}
+		}
+		for _, sc := range t.Columns {
+			found := false
+			for _, c := range s.Columns {
+				if c.Name == sc.Name {
+					found = true
+					break
+				}
+			}
+			if !found {
+				report("", "column %s of table %s (line %d) has no corresponding field", sc.Name, t.Name, sc.Line)
+			}
+		}
+	}
+	return mismatches, nil
+}
+
+// collapseArrayDims returns dataType with any array dimensions collapsed to
+// one (e.g. int4[][] -> int4[]). PostgreSQL does not enforce the declared
+// number of dimensions, and pg_dump declares a single dimension.
+func collapseArrayDims(dataType string) string {
+	if !strings.HasSuffix(dataType, "[]") {
+		return dataType
+	}
+	for strings.HasSuffix(dataType, "[]") {
+		dataType = strings.TrimSuffix(dataType, "[]")
+	}
+	return dataType + "[]"
+}
+
+func nullability(notNull bool) string {
+	if notNull {
+		return "NOT NULL"
+	}
+	return "nullable"
+}
+
+// fieldPositions parses the Go source file at path, returning the positions of
+// struct types keyed by name, and of struct fields keyed by {struct}.{field}
+func fieldPositions(path string) (map[string]token.Position, error) {
+	fset := token.NewFileSet()
+	af, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	positions := map[string]token.Position{}
+	ast.Inspect(af, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		positions[ts.Name.Name] = fset.Position(ts.Pos())
+		for _, field := range st.Fields.List {
+			for _, name := range field.Names {
+				positions[ts.Name.Name+"."+name.Name] = fset.Position(name.Pos())
+			}
+		}
+		return false
+	})
+	return positions, nil
+}
+
+// stripSQLComments replaces -- and /* */ comments within src with spaces,
+// preserving line breaks and offsets
+func stripSQLComments(src string) string {
+	b := []byte(src)
+	for i := 0; i < len(b); i++ {
+		switch {
+		case b[i] == '\'':
+			for i++; i < len(b) && b[i] != '\''; i++ {
+			}
+		case b[i] == '-' && i+1 < len(b) && b[i+1] == '-':
+			for ; i < len(b) && b[i] != '\n'; i++ {
+				b[i] = ' '
+			}
+		case b[i] == '/' && i+1 < len(b) && b[i+1] == '*':
+			for ; i < len(b) && !(b[i] == '*' && i+1 < len(b) && b[i+1] == '/'); i++ {
+				if b[i] != '\n' {
+					b[i] = ' '
+				}
+			}
+			if i+1 < len(b) {
+				b[i], b[i+1] = ' ', ' '
+				i++
+			}
+		}
+	}
+	return string(b)
+}
+
+// splitSQLStatements returns the [start, end) offsets of each statement within
+// src, ignoring semicolons within quotes or dollar-quoted strings
+func splitSQLStatements(src string) [][2]int {
+	var stmts [][2]int
+	start := 0
+	for i := 0; i < len(src); i++ {
+		switch src[i] {
+		case '\'', '"':
+			q := src[i]
+			for i++; i < len(src) && src[i] != q; i++ {
+			}
+		case '$':
+			end := strings.IndexByte(src[i+1:], '$')
+			if end < 0 {
+				continue
+			}
+			tag := src[i : i+end+2]
+			if strings.IndexFunc(tag[1:len(tag)-1], func(r rune) bool { return r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) }) >= 0 {
+				continue
+			}
+			close := strings.Index(src[i+len(tag):], tag)
+			if close < 0 {
+				i = len(src)
+				continue
+			}
+			i += len(tag) + close + len(tag) - 1
+		case ';':
+			stmts = append(stmts, [2]int{start, i})
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(src[start:]) != "" {
+		stmts = append(stmts, [2]int{start, len(src)})
+	}
+	return stmts
+}
+
+// sqlParenBody returns the text between the parenthesis at src[open] and its
+// matching close parenthesis, along with the offset of the close parenthesis
+// relative to open+1 (or -1)
+func sqlParenBody(src string, open int) (string, int) {
+	depth := 0
+	for i := open; i < len(src); i++ {
+		switch src[i] {
+		case '\'', '"':
+			q := src[i]
+			for i++; i < len(src) && src[i] != q; i++ {
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return src[open+1 : i], i - open - 1
+			}
+		}
+	}
+	return "", -1
+}
+
+// splitSQLList returns the [start, end) offsets of each comma-separated item
+// within body, ignoring commas within parentheses or quotes
+func splitSQLList(body string) [][2]int {
+	var items [][2]int
+	depth, start := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\'', '"':
+			q := body[i]
+			for i++; i < len(body) && body[i] != q; i++ {
+			}
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				items = append(items, [2]int{start, i})
+				start = i + 1
+			}
+		}
+	}
+	return append(items, [2]int{start, len(body)})
+}
+
+// sqlParenNames returns the names within the first parenthesized list of def
+func sqlParenNames(def string) []string {
+	open := strings.IndexByte(def, '(')
+	if open < 0 {
+		return nil
+	}
+	body, close := sqlParenBody(def, open)
+	if close < 0 {
+		return nil
+	}
+	var names []string
+	for _, item := range splitSQLList(body) {
+		names = append(names, unquoteSQLName(strings.TrimSpace(body[item[0]:item[1]])))
+	}
+	return names
+}
+
+// unquoteSQLName removes double quotes from a (possibly qualified) name, and
+// folds unquoted parts to lower case
+func unquoteSQLName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		if len(part) > 1 && part[0] == '"' && part[len(part)-1] == '"' {
+			parts[i] = strings.Replace(part[1:len(part)-1], `""`, `"`, -1)
+			continue
+		}
+		parts[i] = strings.Map(unicode.ToLower, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+func lineAt(src string, offset int) int {
+	return strings.Count(src[:offset], "\n") + 1
+}
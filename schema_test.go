@@ -0,0 +1,118 @@
+package pgxgen
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/wdamron/astx"
+)
+
+func TestCheckSchemaGenDDL(t *testing.T) {
+	af, err := astx.ParseFile(filepath.Join("example", "example.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := NewFile(af)
+	schema, err := ParseSchema(string(f.GenDDL()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mismatches, err := f.CheckSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mismatches {
+		t.Error(m)
+	}
+}
+
+func TestCheckSchemaDump(t *testing.T) {
+	f := parseTestFile(t, "test.go", "package p\n\ntype Row struct {\n"+
+		"\t_ struct{} `pgx:\"table:rows\"`\n"+
+		"\tID int64 `pgx:\"name:id;type:int8;pk\"`\n"+
+		"\tT *time.Time `pgx:\"name:ts;type:timestampTz\"`\n"+
+		"\tM [][]float64 `pgx:\"name:m;type:float8[][]\"`\n"+
+		"\tV string `pgx:\"name:v;type:varchar;unique\"`\n"+
+		"}\n")
+	// as written by pg_dump:
+	schema, err := ParseSchema(`
+CREATE TABLE public.rows (
+    id bigint NOT NULL,
+    ts timestamp with time zone,
+    m double precision[] NOT NULL,
+    v character varying(40) NOT NULL
+);
+
+ALTER TABLE ONLY public.rows
+    ADD CONSTRAINT rows_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.rows
+    ADD CONSTRAINT rows_v_key UNIQUE (v);
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := schema.Table("rows")
+	if table == nil {
+		t.Fatal("table rows not found")
+	}
+	if !reflect.DeepEqual(table.PrimaryKey, []string{"id"}) {
+		t.Errorf("PrimaryKey = %v", table.PrimaryKey)
+	}
+	if want := []SchemaUnique{{Name: "rows_v_key", Columns: []string{"v"}}}; !reflect.DeepEqual(table.Unique, want) {
+		t.Errorf("Unique = %v", table.Unique)
+	}
+	mismatches, err := f.CheckSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range mismatches {
+		t.Error(m)
+	}
+
+	// unknown columns and mismatched types are reported
+	schema, err = ParseSchema("CREATE TABLE rows (id int4 PRIMARY KEY, ts timestamptz, m float8[] NOT NULL, v text NOT NULL, x int4);")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mismatches, err = f.CheckSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+	if len(mismatches) != 3 {
+		t.Errorf("expected 3 mismatches, got %q", mismatches)
+	}
+}
+
+func TestNormalizeSchemaType(t *testing.T) {
+	for dataType, want := range map[string]string{
+		"timestamptz":                 "timestampTz",
+		"TIMESTAMPTZ[]":               "timestampTz[]",
+		"timestamp(3) with time zone": "timestampTz",
+		"character varying(255)":      "varchar",
+		"double precision[]":          "float[]",
+		"float8[][]":                  "float[][]",
+		"numeric(12,2)":               "numeric",
+		"public.mood":                 "mood",
+	} {
+		if got := NormalizeSchemaType(dataType); got != want {
+			t.Errorf("NormalizeSchemaType(%q) = %q; want %q", dataType, got, want)
+		}
+	}
+}
+
+func TestAlterSchemaTable(t *testing.T) {
+	sc := &Schema{Tables: []*SchemaTable{{Name: "public.rows"}}}
+	// statements without a constraint are ignored
+	for _, stmt := range []string{
+		"ALTER TABLE rows ADD",
+		"ALTER TABLE rows OWNER TO app",
+		"ALTER TABLE IF EXISTS",
+	} {
+		alterSchemaTable(sc, stmt)
+	}
+	alterSchemaTable(sc, "ALTER TABLE ONLY rows\n\tADD PRIMARY KEY (id)")
+	if pk := sc.Tables[0].PrimaryKey; !reflect.DeepEqual(pk, []string{"id"}) {
+		t.Errorf("PrimaryKey = %v", pk)
+	}
+}
@@ -1,21 +1,38 @@
 package pgxgen
 
 import (
+	"fmt"
+	"unicode"
+
 	"github.com/wdamron/astx"
 )
 
+const (
+	TableNameKey     = "table"
+	TableConflictKey = "conflict"
+)
+
 type Struct struct {
 	astx.Struct
+	Table   string
+	Spec    map[string]string
 	Columns []Column
 }
 
 func NewStruct(as *astx.Struct) *Struct {
-	s := &Struct{Struct: *as}
+	s := &Struct{Struct: *as, Table: DefaultTableName(as.Name)}
 	cols := []Column{}
 	for i, f := range s.Struct.Fields {
 		if !IsColumn(f) {
 			continue
 		}
+		if IsTableSpec(f) {
+			s.Spec = GetFieldColumnSpec(&s.Struct.Fields[i])
+			if table := s.Spec[TableNameKey]; table != "" {
+				s.Table = table
+			}
+			continue
+		}
 		col := NewColumn(&s.Struct.Fields[i])
 		cols = append(cols, *col)
 	}
@@ -24,3 +41,88 @@ func NewStruct(as *astx.Struct) *Struct {
 	}
 	return s
 }
+
+// IsTableSpec reports whether f is a blank field holding table-level options,
+// e.g. _ struct{} `pgx:"table:points"`
+func IsTableSpec(f astx.StructField) bool {
+	return f.Name == "_"
+}
+
+// DefaultTableName converts a struct name to snake case, for use as the table
+// name when no table option is given (e.g. UserAccount -> user_account).
+func DefaultTableName(structName string) string {
+	runes := []rune(structName)
+	out := make([]rune, 0, len(runes)+4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				out = append(out, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// PKColumns returns the primary key columns of s, in field order.
+func (s *Struct) PKColumns() []Column {
+	var pk []Column
+	for _, c := range s.Columns {
+		if c.IsPK() {
+			pk = append(pk, c)
+		}
+	}
+	return pk
+}
+
+// UniqueGroups returns the names of the columns within each unique constraint
+// of s, keyed by constraint name (see Column.UniqueGroup).
+func (s *Struct) UniqueGroups() (groups map[string][]string, order []string) {
+	groups = map[string][]string{}
+	for _, c := range s.Columns {
+		group := c.UniqueGroup()
+		if group == "" {
+			continue
+		}
+		if groups[group] == nil {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], c.Name)
+	}
+	return groups, order
+}
+
+// ConflictTarget returns the names of the columns used as the ON CONFLICT
+// target for upserts into the table for s.
+//
+// The conflict option of the table spec may name a unique group, or "pk".
+// Otherwise the primary key is used, or the only unique group if there is no
+// primary key. If no target can be determined, the returned slice will be nil.
+func (s *Struct) ConflictTarget() ([]string, error) {
+	groups, order := s.UniqueGroups()
+	var pk []string
+	for _, c := range s.PKColumns() {
+		pk = append(pk, c.Name)
+	}
+	switch conflict := s.Spec[TableConflictKey]; conflict {
+	case "":
+	case ColumnPKKey:
+		if len(pk) == 0 {
+			return nil, fmt.Errorf("conflict target pk has no columns in struct %s", s.Name)
+		}
+		return pk, nil
+	default:
+		if groups[conflict] == nil {
+			return nil, fmt.Errorf("conflict target %s not found in struct %s", conflict, s.Name)
+		}
+		return groups[conflict], nil
+	}
+	if len(pk) != 0 {
+		return pk, nil
+	}
+	if len(order) == 1 {
+		return groups[order[0]], nil
+	}
+	return nil, nil
+}